@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type abilityPokemon struct {
+	Name   string `json:"name"`
+	Hidden bool   `json:"hidden"`
+	Caught bool   `json:"caught"`
+}
+
+type abilityResult struct {
+	Name       string           `json:"name"`
+	Generation string           `json:"generation"`
+	Effect     string           `json:"effect"`
+	Pokemon    []abilityPokemon `json:"pokemon"`
+}
+
+func (r abilityResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Name: %s\n", r.Name)
+	fmt.Fprintf(w, "Generation: %s\n", r.Generation)
+	fmt.Fprintf(w, "Effect: %s\n", r.Effect)
+	fmt.Fprintln(w, "Pokemon:")
+
+	for _, pokemon := range r.Pokemon {
+		fmt.Fprintf(w, "   - %s\n", pokemon.label())
+	}
+
+	fmt.Fprintln(w)
+}
+
+func (p abilityPokemon) label() string {
+	label := p.Name
+	if p.Hidden {
+		label += " (hidden)"
+	}
+	if p.Caught {
+		label += " *"
+	}
+	return label
+}
+
+func (r abilityResult) tableRows() ([]string, [][]string) {
+	rows := [][]string{}
+	for _, pokemon := range r.Pokemon {
+		hidden, caught := "", ""
+		if pokemon.Hidden {
+			hidden = "yes"
+		}
+		if pokemon.Caught {
+			caught = "yes"
+		}
+		rows = append(rows, []string{pokemon.Name, hidden, caught})
+	}
+	return []string{"POKEMON", "HIDDEN", "CAUGHT"}, rows
+}
+
+func (config *Config) getAbility(name string) (Ability, error) {
+	ability := Ability{}
+	err := config.fetch(config.Ability+name, &ability)
+	if errors.Is(err, errNotFound) {
+		return ability, errors.New("invalid ability name.")
+	}
+	return ability, err
+}
+
+func commandAbility(config *Config, args cmdArgs) (any, error) {
+	ability, err := config.getAbility(args.arg(0))
+	if err != nil {
+		return nil, err
+	}
+
+	result := abilityResult{
+		Name:       ability.Name,
+		Generation: ability.Generation.Name,
+		Effect:     abilityEffect(ability),
+		Pokemon:    []abilityPokemon{},
+	}
+
+	for _, pokemon := range ability.Pokemon {
+		result.Pokemon = append(result.Pokemon, abilityPokemon{
+			Name:   pokemon.Pokemon.Name,
+			Hidden: pokemon.IsHidden,
+			Caught: config.ownsPokemon(pokemon.Pokemon.Name),
+		})
+	}
+
+	return result, nil
+}
+
+// abilityEffect returns the English effect, falling back to the flavor text
+// for abilities the API has no effect entry for.
+func abilityEffect(ability Ability) string {
+	for _, entry := range ability.EffectEntries {
+		if entry.Language.Name == language {
+			return strings.Join(strings.Fields(entry.Effect), " ")
+		}
+	}
+
+	text := ""
+	for _, entry := range ability.FlavorTextEntries {
+		if entry.Language.Name == language {
+			text = entry.FlavorText
+		}
+	}
+	return strings.Join(strings.Fields(text), " ")
+}
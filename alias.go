@@ -0,0 +1,274 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// maxAliasDepth bounds how deeply aliases may expand into other aliases.
+const maxAliasDepth = 16
+
+// userConfig is the part of Config saved in the user's config file.
+type userConfig struct {
+	Aliases map[string]string `json:"aliases"`
+}
+
+type aliasInfo struct {
+	Name      string `json:"name"`
+	Expansion string `json:"expansion"`
+}
+
+type aliasList struct {
+	Aliases []aliasInfo `json:"aliases"`
+}
+
+func (r aliasList) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+
+	if len(r.Aliases) == 0 {
+		fmt.Fprintln(w, "no aliases defined.")
+		fmt.Fprintln(w)
+		return
+	}
+
+	for _, alias := range r.Aliases {
+		fmt.Fprintf(w, "%s = %s\n", alias.Name, alias.Expansion)
+	}
+
+	fmt.Fprintln(w)
+}
+
+func (r aliasList) tableRows() ([]string, [][]string) {
+	rows := [][]string{}
+	for _, alias := range r.Aliases {
+		rows = append(rows, []string{alias.Name, alias.Expansion})
+	}
+	return []string{"ALIAS", "EXPANSION"}, rows
+}
+
+func (config *Config) aliasList() aliasList {
+	list := aliasList{Aliases: []aliasInfo{}}
+	for name, expansion := range config.Aliases {
+		list.Aliases = append(list.Aliases, aliasInfo{Name: name, Expansion: expansion})
+	}
+	sort.Slice(list.Aliases, func(i, j int) bool {
+		return list.Aliases[i].Name < list.Aliases[j].Name
+	})
+	return list
+}
+
+func loadUserConfig(config *Config) error {
+	path, err := configPath("config.json")
+	if err != nil {
+		return err
+	}
+
+	body, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	saved := userConfig{}
+	err = json.Unmarshal(body, &saved)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	// An alias saved before a command of the same name was added would
+	// hide the command, so it is left out.
+	for name, expansion := range saved.Aliases {
+		if _, ok := commands[name]; ok {
+			fmt.Fprintf(os.Stderr, "ignoring alias %q in %s: %q is a built-in command\n", name, path, name)
+			continue
+		}
+		config.Aliases[name] = expansion
+	}
+
+	return nil
+}
+
+func saveUserConfig(config *Config) error {
+	path, err := configPath("config.json")
+	if err != nil {
+		return err
+	}
+
+	body, err := json.MarshalIndent(userConfig{Aliases: config.Aliases}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, body, 0o644)
+}
+
+func commandAlias(config *Config, args cmdArgs) (any, error) {
+	name := args.arg(0)
+
+	if name == "" {
+		return config.aliasList(), nil
+	}
+
+	if len(args.positional) == 1 {
+		expansion, ok := config.Aliases[name]
+		if !ok {
+			return nil, fmt.Errorf("no alias named %q", name)
+		}
+		return aliasList{Aliases: []aliasInfo{{Name: name, Expansion: expansion}}}, nil
+	}
+
+	if _, ok := commands[name]; ok {
+		return nil, fmt.Errorf("%q is a built-in command and cannot be redefined", name)
+	}
+	if strings.ContainsAny(name, " \t;\"'") {
+		return nil, fmt.Errorf("invalid alias name %q", name)
+	}
+
+	expansion := joinExpansion(args.positional[1:])
+	if config.aliasLoops(name, expansion, []string{name}) {
+		return nil, fmt.Errorf("alias %q would expand into itself", name)
+	}
+	config.Aliases[name] = expansion
+
+	err := saveUserConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return message{Message: fmt.Sprintf("alias %s = %s", name, config.Aliases[name])}, nil
+}
+
+func commandUnalias(config *Config, args cmdArgs) (any, error) {
+	name := args.arg(0)
+
+	if _, ok := config.Aliases[name]; !ok {
+		return nil, fmt.Errorf("no alias named %q", name)
+	}
+	delete(config.Aliases, name)
+
+	err := saveUserConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return message{Message: fmt.Sprintf("removed alias %s", name)}, nil
+}
+
+// joinExpansion turns the arguments of "alias NAME ..." back into a command
+// line. A single argument is used as is, so a quoted macro keeps its ";".
+func joinExpansion(words []string) string {
+	if len(words) == 1 {
+		return words[0]
+	}
+
+	quoted := []string{}
+	for _, word := range words {
+		if strings.ContainsAny(word, " \t;") {
+			word = `"` + strings.ReplaceAll(word, `"`, `\"`) + `"`
+		}
+		quoted = append(quoted, word)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// expandAlias returns the command lines an alias stands for, with any extra
+// arguments appended to the last of them.
+// aliasLoops reports whether an expansion runs the alias name again, itself
+// or through other aliases. visited holds the aliases already followed.
+func (config *Config) aliasLoops(name string, expansion string, visited []string) bool {
+	lines, err := expandAlias(expansion, nil)
+	if err != nil {
+		return false
+	}
+
+	for _, line := range lines {
+		next := strings.ToLower(line[0])
+		if _, ok := commands[next]; ok {
+			continue
+		}
+		if next == name {
+			return true
+		}
+		nested, ok := config.Aliases[next]
+		if !ok || slices.Contains(visited, next) {
+			continue
+		}
+		if config.aliasLoops(name, nested, append(slices.Clip(visited), next)) {
+			return true
+		}
+	}
+	return false
+}
+
+func expandAlias(expansion string, extra []string) ([][]string, error) {
+	lines := [][]string{}
+
+	for _, part := range splitCommands(expansion) {
+		tokens, err := tokenize(part)
+		if err != nil {
+			return nil, err
+		}
+		if len(tokens) > 0 {
+			lines = append(lines, tokens)
+		}
+	}
+
+	if len(lines) == 0 {
+		return nil, errors.New("alias expands to nothing")
+	}
+
+	last := len(lines) - 1
+	lines[last] = append(lines[last], extra...)
+
+	return lines, nil
+}
+
+// splitCommands splits a macro on ";" outside of quotes.
+func splitCommands(line string) []string {
+	parts := []string{}
+	start := 0
+	var quote rune
+	escaped := false
+
+	for i, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == ';':
+			parts = append(parts, line[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, line[start:])
+}
+
+func completeAliases(config *Config, before []string) []string {
+	names := []string{}
+	for name := range config.Aliases {
+		names = append(names, name)
+	}
+	return names
+}
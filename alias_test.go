@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAliasLoops(t *testing.T) {
+	commands = getCommands()
+
+	cases := []struct {
+		aliases   map[string]string
+		name      string
+		expansion string
+		expected  bool
+	}{
+		{
+			aliases:   map[string]string{},
+			name:      "c",
+			expansion: "catch",
+			expected:  false,
+		},
+		{
+			aliases:   map[string]string{},
+			name:      "a",
+			expansion: "help; a",
+			expected:  true,
+		},
+		{
+			aliases:   map[string]string{"b": "a"},
+			name:      "a",
+			expansion: "b",
+			expected:  true,
+		},
+		{
+			aliases:   map[string]string{"b": "c pikachu", "c": "explore x; A"},
+			name:      "a",
+			expansion: "help; b",
+			expected:  true,
+		},
+		{
+			// Aliases that loop among themselves are not this one's problem.
+			aliases:   map[string]string{"b": "c", "c": "b"},
+			name:      "a",
+			expansion: "b",
+			expected:  false,
+		},
+		{
+			aliases:   map[string]string{"grind": "explore mt-coronet-1f; catch geodude"},
+			name:      "g",
+			expansion: "grind; bag",
+			expected:  false,
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			config := &Config{Aliases: c.aliases}
+			actual := config.aliasLoops(c.name, c.expansion, []string{c.name})
+			if actual != c.expected {
+				t.Errorf("Expected %v for %s = %q, got %v", c.expected, c.name, c.expansion, actual)
+			}
+		})
+	}
+}
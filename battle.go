@@ -0,0 +1,382 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"pokedex/internal/battle"
+	"slices"
+)
+
+// Battle outcomes.
+const (
+	outcomeWon     = "won"
+	outcomeLost    = "lost"
+	outcomeEscaped = "escaped"
+)
+
+// battleState is the fight between the player's Pokemon and the wild one.
+// HP and status are copied back to the Pokemon after every turn, so they carry
+// over when the battle ends.
+type battleState struct {
+	engine *battle.Battle
+	// Active is the player's Pokemon that is out.
+	Active *CaughtPokemon
+}
+
+var (
+	errInBattle = errors.New("you are in a battle, fight, switch, catch or run first.")
+	errNoBattle = errors.New("you are not in a battle, start one with battle after an encounter.")
+)
+
+type combatantInfo struct {
+	ID     int    `json:"id,omitempty"`
+	Name   string `json:"name"`
+	Level  int    `json:"level"`
+	HP     int    `json:"hp"`
+	MaxHP  int    `json:"max_hp"`
+	Status string `json:"status,omitempty"`
+}
+
+func newCombatantInfo(caught *CaughtPokemon, p *battle.Pokemon) combatantInfo {
+	return combatantInfo{ID: caught.ID, Name: p.Name, Level: p.Level, HP: p.HP, MaxHP: p.MaxHP(), Status: p.Status}
+}
+
+func (c combatantInfo) String() string {
+	description := fmt.Sprintf("%s Lv %d, %d/%d HP", c.Name, c.Level, c.HP, c.MaxHP)
+	if c.Status != "" {
+		description += ", " + c.Status
+	}
+	return description
+}
+
+type battleResult struct {
+	Log    []string      `json:"log"`
+	Player combatantInfo `json:"player"`
+	Wild   combatantInfo `json:"wild"`
+	// Outcome is empty while the battle goes on.
+	Outcome    string          `json:"outcome,omitempty"`
+	Experience *experienceGain `json:"experience,omitempty"`
+}
+
+func (r battleResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	r.renderTurn(w)
+	fmt.Fprintln(w)
+}
+
+// renderTurn prints what happened in a turn and what the player can do next.
+func (r battleResult) renderTurn(w io.Writer) {
+	for _, line := range r.Log {
+		fmt.Fprintln(w, line)
+	}
+
+	switch r.Outcome {
+	case outcomeWon:
+		fmt.Fprintf(w, "You defeated %s!\n", r.Wild.Name)
+		if r.Experience != nil {
+			r.Experience.render(w)
+		}
+	case outcomeLost:
+		fmt.Fprintln(w, "You have no pokemon left that can fight. You blacked out!")
+		fmt.Fprintln(w, "Your party was healed at the Pokemon Center.")
+	case "":
+		fmt.Fprintf(w, "[%s] vs [%s]\n", r.Player, r.Wild)
+		if r.Player.HP == 0 {
+			fmt.Fprintln(w, "Send out another pokemon with switch.")
+		} else {
+			fmt.Fprintf(w, "What will %s do? fight, switch, use, catch or run.\n", r.Player.Name)
+		}
+	}
+}
+
+// combatant turns one of the player's Pokemon or a wild one into a battle
+// Pokemon with its stats and the data of its moves.
+func (config *Config) combatant(caught *CaughtPokemon, name string) (*battle.Pokemon, error) {
+	values, err := config.caughtStats(caught)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &battle.Pokemon{
+		Name:   name,
+		Level:  caught.Level,
+		Types:  pokemonTypes(caught.Pokemon),
+		Stats:  values,
+		HP:     caught.HP,
+		Status: caught.Status,
+		Moves:  []battle.Move{},
+	}
+
+	for _, name := range caught.Moves {
+		move, err := config.getMove(name)
+		if err != nil {
+			return nil, err
+		}
+		p.Moves = append(p.Moves, battleMove(move))
+	}
+
+	return p, nil
+}
+
+func battleMove(move Move) battle.Move {
+	m := battle.Move{
+		Name:          move.Name,
+		Type:          move.Type.Name,
+		DamageClass:   move.DamageClass.Name,
+		Priority:      move.Priority,
+		Ailment:       move.Meta.Ailment.Name,
+		AilmentChance: move.Meta.AilmentChance,
+	}
+	if move.Power != nil {
+		m.Power = *move.Power
+	}
+	if move.Accuracy != nil {
+		m.Accuracy = *move.Accuracy
+	}
+	return m
+}
+
+// partyLead returns the first Pokemon in the party that can still fight.
+func (config *Config) partyLead() (*CaughtPokemon, bool) {
+	for _, id := range config.Party {
+		if caught := config.Owned[id]; !caught.Fainted() {
+			return caught, true
+		}
+	}
+	return nil, false
+}
+
+func commandBattle(config *Config, args cmdArgs) (any, error) {
+	if config.Battle != nil {
+		return nil, errInBattle
+	}
+	if config.Wild == nil {
+		return nil, errors.New("there is no wild pokemon to fight, look for one with encounter first.")
+	}
+
+	lead, ok := config.partyLead()
+	if !ok {
+		return nil, errors.New("you have no pokemon that can fight, heal your party first.")
+	}
+
+	player, err := config.combatant(lead, lead.Name())
+	if err != nil {
+		return nil, err
+	}
+	wild, err := config.combatant(config.Wild, "the wild "+config.Wild.Pokemon.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	config.Battle = &battleState{engine: battle.New(player, wild, config.TypeChart, config.Rand), Active: lead}
+
+	return config.battleResult([]string{
+		fmt.Sprintf("A wild %s (Lv %d) wants to fight!", config.Wild.Pokemon.Name, config.Wild.Level),
+		fmt.Sprintf("Go, %s!", lead.Name()),
+	}), nil
+}
+
+func commandFight(config *Config, args cmdArgs) (any, error) {
+	state := config.Battle
+	if state == nil {
+		return nil, errNoBattle
+	}
+	if state.Active.Fainted() {
+		return nil, fmt.Errorf("%s has fainted, send out another pokemon with switch.", state.Active.Name())
+	}
+
+	player := state.engine.Player
+	name := args.arg(0)
+	move, ok := player.Move(name)
+	if !ok && len(player.Moves) == 0 && name == battle.Struggle.Name {
+		move, ok = battle.Struggle, true
+	}
+	if !ok {
+		return nil, fmt.Errorf("%s doesn't know %s.", state.Active.Name(), name)
+	}
+
+	log, err := state.engine.Fight(move)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.finishTurn(log)
+}
+
+func commandSwitch(config *Config, args cmdArgs) (any, error) {
+	state := config.Battle
+	if state == nil {
+		return nil, errNoBattle
+	}
+
+	caught, err := config.findOwned(args.arg(0))
+	if err != nil {
+		return nil, err
+	}
+
+	switch s, _ := config.locate(caught.ID); {
+	case s.box != 0:
+		return nil, fmt.Errorf("%s is not in your party.", caught.Name())
+	case caught == state.Active:
+		return nil, fmt.Errorf("%s is already out.", caught.Name())
+	case caught.Fainted():
+		return nil, fmt.Errorf("%s has fainted and can't fight.", caught.Name())
+	}
+
+	p, err := config.combatant(caught, caught.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	log, err := state.engine.Switch(p)
+	if err != nil {
+		return nil, err
+	}
+	state.Active = caught
+
+	return config.finishTurn(log)
+}
+
+// runFromBattle tries to get away from the wild Pokemon, which is gone when
+// that works.
+func (config *Config) runFromBattle() (any, error) {
+	state := config.Battle
+	if state.Active.Fainted() {
+		return nil, fmt.Errorf("%s has fainted, send out another pokemon with switch.", state.Active.Name())
+	}
+
+	escaped, log, err := state.engine.Run()
+	if err != nil {
+		return nil, err
+	}
+	if !escaped {
+		return config.finishTurn(log)
+	}
+
+	result := config.battleResult(log)
+	result.Outcome = outcomeEscaped
+	config.Battle = nil
+	config.Wild = nil
+	return result, nil
+}
+
+// wildTurn lets the wild Pokemon attack after the player used their turn on
+// something other than a move.
+func (config *Config) wildTurn() (battleResult, error) {
+	log, err := config.Battle.engine.WildTurn()
+	if err != nil {
+		return battleResult{}, err
+	}
+	return config.endTurn(log)
+}
+
+// catchInBattle ends the battle when the wild Pokemon was caught, and lets it
+// attack otherwise.
+func (config *Config) catchInBattle(caught catchResult) (any, error) {
+	if caught.Caught {
+		config.Battle = nil
+		config.Wild = nil
+		return caught, nil
+	}
+
+	result, err := config.wildTurn()
+	if err != nil {
+		return nil, err
+	}
+	caught.Battle = &result
+	return caught, nil
+}
+
+// endTurn copies HP and status back to the Pokemon and ends the battle when
+// the wild Pokemon fainted or the player has no Pokemon left that can fight.
+func (config *Config) endTurn(log []string) (battleResult, error) {
+	config.Battle.sync(config.Wild)
+
+	result := config.battleResult(log)
+
+	if config.Wild.Fainted() {
+		result.Outcome = outcomeWon
+		gain, err := config.awardExperience(config.Wild)
+		if err != nil {
+			return battleResult{}, err
+		}
+		result.Experience = gain
+	} else if _, ok := config.partyLead(); !ok {
+		result.Outcome = outcomeLost
+		err := config.healParty()
+		if err != nil {
+			return battleResult{}, err
+		}
+	}
+
+	if result.Outcome != "" {
+		config.Battle = nil
+		config.Wild = nil
+	}
+	return result, nil
+}
+
+// finishTurn ends a turn for a command that returns the battle result.
+func (config *Config) finishTurn(log []string) (any, error) {
+	result, err := config.endTurn(log)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// sync copies HP and status from the battle to the Pokemon fighting it.
+func (s *battleState) sync(wild *CaughtPokemon) {
+	s.Active.HP, s.Active.Status = s.engine.Player.HP, s.engine.Player.Status
+	wild.HP, wild.Status = s.engine.Wild.HP, s.engine.Wild.Status
+}
+
+// reload copies HP and status of the player's Pokemon to the battle, after an
+// item was used on it.
+func (s *battleState) reload() {
+	s.engine.Player.HP, s.engine.Player.Status = s.Active.HP, s.Active.Status
+}
+
+func (config *Config) battleResult(log []string) battleResult {
+	state := config.Battle
+	return battleResult{
+		Log:    log,
+		Player: newCombatantInfo(state.Active, state.engine.Player),
+		Wild:   newCombatantInfo(config.Wild, state.engine.Wild),
+	}
+}
+
+// healParty restores the HP of the player's party and cures their status
+// conditions.
+func (config *Config) healParty() error {
+	for _, id := range config.Party {
+		caught := config.Owned[id]
+		maxHP, err := config.maxHP(caught)
+		if err != nil {
+			return err
+		}
+		caught.HP = maxHP
+		caught.Status = ""
+	}
+	return nil
+}
+
+func commandHeal(config *Config, args cmdArgs) (any, error) {
+	if config.Battle != nil {
+		return nil, errInBattle
+	}
+
+	err := config.healParty()
+	if err != nil {
+		return nil, err
+	}
+	return message{Message: "Your party was healed at the Pokemon Center. We hope to see you again!"}, nil
+}
+
+func completeFight(config *Config, before []string) []string {
+	if config.Battle == nil {
+		return nil
+	}
+	return slices.Clone(config.Battle.Active.Moves)
+}
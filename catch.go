@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"pokedex/internal/capture"
+	"slices"
+)
+
+// ballBonuses are the catch rate multipliers of balls that always have the
+// same effect. Balls not listed here or in ballBonus work like a Poke Ball.
+var ballBonuses = map[string]float64{
+	"great-ball":  1.5,
+	"ultra-ball":  2,
+	"master-ball": 255,
+	"safari-ball": 1.5,
+	"sport-ball":  1.5,
+}
+
+// ballBonus returns the catch rate multiplier of a ball thrown at a Pokemon.
+func (config *Config) ballBonus(ball string, pokemon Pokemon) float64 {
+	switch ball {
+	case "net-ball":
+		types := pokemonTypes(pokemon)
+		if slices.Contains(types, "water") || slices.Contains(types, "bug") {
+			return 3
+		}
+	case "repeat-ball":
+		if config.hasCaughtSpecies(pokemon.Species.Name) {
+			return 3
+		}
+	}
+
+	if bonus, ok := ballBonuses[ball]; ok {
+		return bonus
+	}
+	return 1
+}
+
+// ballItem returns the ball to throw, failing when it is not in the bag or
+// is not a ball.
+func (config *Config) ballItem(ball string) (Item, error) {
+	if config.Bag[ball] == 0 {
+		return Item{}, fmt.Errorf("you don't have any %s.", ball)
+	}
+
+	item, err := config.getItem(ball)
+	if err != nil {
+		return Item{}, err
+	}
+	if itemKind(item) != itemBall {
+		return Item{}, fmt.Errorf("%s is not a ball.", item.Name)
+	}
+	return item, nil
+}
+
+// throwBall throws a ball from the bag at a wild Pokemon, adding it to the
+// player's Pokemon when it is caught. The ball is used up either way.
+func (config *Config) throwBall(wild *CaughtPokemon, ball string) (catchResult, error) {
+	pokemon := wild.Pokemon
+
+	item, err := config.ballItem(ball)
+	if err != nil {
+		return catchResult{}, err
+	}
+
+	species, err := config.getSpeciesOf(pokemon)
+	if err != nil {
+		return catchResult{}, err
+	}
+
+	maxHP, err := config.maxHP(wild)
+	if err != nil {
+		return catchResult{}, err
+	}
+
+	attempt := capture.Attempt{
+		CaptureRate: species.CaptureRate,
+		MaxHP:       maxHP,
+		HP:          wild.HP,
+		Ball:        config.ballBonus(item.Name, pokemon),
+		Status:      wild.Status,
+	}
+
+	shakes, caught := attempt.Throw(config.Rand.Intn)
+	config.takeItem(item.Name)
+
+	result := catchResult{Pokemon: pokemon.Name, Level: wild.Level, Ball: item.Name, Shakes: shakes, Caught: caught}
+
+	if caught {
+		config.NextID++
+		wild.ID = config.NextID
+		wild.Ball = item.Name
+		wild.CaughtIn = config.Area
+		wild.CaughtAt = config.Clock()
+		wild.MetLevel = wild.Level
+
+		result.ID = wild.ID
+		result.SentTo = config.addCaught(wild).String()
+
+		result.Experience, err = config.awardExperience(wild)
+		if err != nil {
+			return catchResult{}, err
+		}
+	}
+
+	return result, nil
+}
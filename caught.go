@@ -0,0 +1,335 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"pokedex/internal/experience"
+	"pokedex/internal/stats"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// natures lists the 25 natures a Pokemon can have.
+var natures = []string{
+	"hardy", "lonely", "brave", "adamant", "naughty",
+	"bold", "docile", "relaxed", "impish", "lax",
+	"timid", "hasty", "serious", "jolly", "naive",
+	"modest", "mild", "quiet", "bashful", "rash",
+	"calm", "gentle", "sassy", "careful", "quirky",
+}
+
+// statNames are the stats IVs and EVs are kept for, in the order the API
+// lists them.
+var statNames = []string{stats.HP, stats.Attack, stats.Defense, stats.SpecialAttack, stats.SpecialDefense, stats.Speed}
+
+// shinyOdds is the 1 in shinyOdds chance a caught Pokemon is shiny.
+const shinyOdds = 4096
+
+// DexEntry records what the player knows of a species.
+type DexEntry struct {
+	Species string `json:"species"`
+	// Number is the species' national Pokedex number.
+	Number int  `json:"number"`
+	Caught bool `json:"caught"`
+	// SeenAt, SeenIn and SeenBy record when, in which area and how the
+	// species was first seen: by exploring, with an encounter method, by
+	// catching or by evolving one.
+	SeenAt time.Time `json:"seen_at"`
+	SeenIn string    `json:"seen_in,omitempty"`
+	SeenBy string    `json:"seen_by,omitempty"`
+}
+
+// maxMoves is how many moves a Pokemon can know at once.
+const maxMoves = 4
+
+// CaughtPokemon is one Pokemon the player owns. Wild Pokemon are rolled the
+// same way, and only get an ID when they are caught.
+type CaughtPokemon struct {
+	ID         int            `json:"id"`
+	Species    string         `json:"species"`
+	Nickname   string         `json:"nickname,omitempty"`
+	Level      int            `json:"level"`
+	Experience int            `json:"experience"`
+	Nature     string         `json:"nature"`
+	IVs        map[string]int `json:"ivs"`
+	EVs        map[string]int `json:"evs"`
+	Gender     string         `json:"gender"`
+	Shiny      bool           `json:"shiny"`
+	Ball       string         `json:"ball"`
+	CaughtIn   string         `json:"caught_in"`
+	CaughtAt   time.Time      `json:"caught_at"`
+	MetLevel   int            `json:"met_level"`
+	HP         int            `json:"hp"`
+	Status     string         `json:"status,omitempty"`
+	Moves      []string       `json:"moves"`
+	Friendship int            `json:"friendship"`
+	// Evolving is the species the Pokemon is about to evolve into after a
+	// level-up, until it evolves or the evolution is canceled.
+	Evolving string `json:"evolving,omitempty"`
+	// PendingMoves are moves the Pokemon reached the level for while it
+	// knew four moves already.
+	PendingMoves []string `json:"pending_moves,omitempty"`
+
+	// Pokemon is the API data of the Pokemon's form.
+	Pokemon Pokemon `json:"-"`
+}
+
+// rollInstance rolls the individual traits of a wild Pokemon, which is met
+// at full health knowing the last moves it learned by leveling up.
+func (config *Config) rollInstance(pokemon Pokemon, species PokemonSpecies, level int) (*CaughtPokemon, error) {
+	points, err := experience.ForLevel(species.GrowthRate.Name, level)
+	if err != nil {
+		return nil, err
+	}
+
+	wild := &CaughtPokemon{
+		Species:    species.Name,
+		Experience: points,
+		Friendship: species.BaseHappiness,
+		Level:      level,
+		Nature:     natures[config.Rand.Intn(len(natures))],
+		IVs:        map[string]int{},
+		EVs:        map[string]int{},
+		Gender:     rollGender(config.Rand, species.GenderRate),
+		Shiny:      config.Rand.Intn(shinyOdds) == 0,
+		Moves:      defaultMoves(pokemon, level),
+		Pokemon:    pokemon,
+	}
+
+	for _, stat := range statNames {
+		wild.IVs[stat] = config.Rand.Intn(stats.MaxIV + 1)
+		wild.EVs[stat] = 0
+	}
+
+	maxHP, err := config.maxHP(wild)
+	if err != nil {
+		return nil, err
+	}
+	wild.HP = maxHP
+
+	return wild, nil
+}
+
+// defaultMoves returns the last moves a Pokemon learns by leveling up to the
+// level in the newest game it appears in.
+func defaultMoves(pokemon Pokemon, level int) []string {
+	moves := []string{}
+	for _, move := range newMovesResult(pokemon, latestVersionGroup(pokemon), "level-up").Moves {
+		if move.Level <= level && !slices.Contains(moves, move.Name) {
+			moves = append(moves, move.Name)
+		}
+	}
+	return moves[max(0, len(moves)-maxMoves):]
+}
+
+func (config *Config) getNature(name string) (stats.Nature, error) {
+	nature := Nature{}
+	err := config.fetch(config.Nature+name, &nature)
+	if errors.Is(err, errNotFound) {
+		return stats.Nature{}, errors.New("invalid nature name.")
+	}
+	if err != nil {
+		return stats.Nature{}, err
+	}
+
+	result := stats.Nature{Name: nature.Name}
+	if nature.IncreasedStat != nil {
+		result.Increased = nature.IncreasedStat.Name
+	}
+	if nature.DecreasedStat != nil {
+		result.Decreased = nature.DecreasedStat.Name
+	}
+	return result, nil
+}
+
+// caughtStats calculates the Pokemon's stats from its base stats, level, IVs, EVs
+// and nature.
+func (config *Config) caughtStats(caught *CaughtPokemon) (map[string]int, error) {
+	nature, err := config.getNature(caught.Nature)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]int{}
+	for _, stat := range caught.Pokemon.Stats {
+		name := stat.Stat.Name
+		values[name] = stats.Calc(name, stat.BaseStat, caught.IVs[name], caught.EVs[name], caught.Level, nature)
+	}
+	return values, nil
+}
+
+func (config *Config) maxHP(caught *CaughtPokemon) (int, error) {
+	values, err := config.caughtStats(caught)
+	if err != nil {
+		return 0, err
+	}
+	return values[stats.HP], nil
+}
+
+// Fainted reports whether the Pokemon has no HP left.
+func (c *CaughtPokemon) Fainted() bool {
+	return c.HP <= 0
+}
+
+// rollGender picks a gender from the species' gender rate, the chance of
+// being female in eighths, or -1 for genderless species.
+func rollGender(rng *rand.Rand, rate int) string {
+	switch {
+	case rate < 0:
+		return "genderless"
+	case rng.Intn(8) < rate:
+		return "female"
+	}
+	return "male"
+}
+
+// Name returns the nickname of the Pokemon, or its name when it has none.
+func (c *CaughtPokemon) Name() string {
+	if c.Nickname != "" {
+		return c.Nickname
+	}
+	return c.Pokemon.Name
+}
+
+// Ways of seeing a Pokemon other than encounter methods.
+const (
+	seenExploring = "exploring"
+	seenCatching  = "catching"
+	seenEvolving  = "evolving"
+)
+
+// markSeen adds the species of a Pokemon to the Pokedex, recording where and
+// how it was seen the first time.
+func (config *Config) markSeen(pokemon Pokemon, how string) *DexEntry {
+	species := pokemon.Species.Name
+	entry, ok := config.Pokedex[species]
+	if !ok {
+		entry = &DexEntry{
+			Species: species,
+			Number:  resourceID(pokemon.Species.URL),
+			SeenAt:  config.Clock(),
+			SeenIn:  config.Area,
+			SeenBy:  how,
+		}
+		config.Pokedex[species] = entry
+	}
+	return entry
+}
+
+// addCaught registers a caught Pokemon in the Pokedex and the player's
+// Pokemon, returning the slot it was put in.
+func (config *Config) addCaught(caught *CaughtPokemon) slot {
+	config.markSeen(caught.Pokemon, seenCatching).Caught = true
+	config.Owned[caught.ID] = caught
+	return config.store(caught.ID)
+}
+
+// ownedIDs returns the IDs of the player's Pokemon in ascending order.
+func (config *Config) ownedIDs() []int {
+	ids := []int{}
+	for id := range config.Owned {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// findOwned looks up one of the player's Pokemon by ID, or by nickname,
+// Pokemon or species name, picking the one caught first when several match.
+func (config *Config) findOwned(arg string) (*CaughtPokemon, error) {
+	if id, err := strconv.Atoi(arg); err == nil {
+		if caught, ok := config.Owned[id]; ok {
+			return caught, nil
+		}
+		return nil, fmt.Errorf("you have no pokemon with ID %d.", id)
+	}
+
+	for _, id := range config.ownedIDs() {
+		caught := config.Owned[id]
+		if strings.EqualFold(caught.Nickname, arg) || caught.Pokemon.Name == arg || caught.Species == arg {
+			return caught, nil
+		}
+	}
+
+	return nil, errors.New("you have not caught that pokemon.")
+}
+
+// ownsPokemon reports whether the player owns a Pokemon of the given form.
+func (config *Config) ownsPokemon(name string) bool {
+	for _, caught := range config.Owned {
+		if caught.Pokemon.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CaughtPokemon) renderTraits(w io.Writer) {
+	fmt.Fprintf(w, "Level: %d\n", c.Level)
+	fmt.Fprintf(w, "Experience: %d\n", c.Experience)
+	fmt.Fprintf(w, "HP: %s\n", c.healthDescription())
+	fmt.Fprintf(w, "Moves: %s\n", strings.Join(c.Moves, ", "))
+	fmt.Fprintf(w, "Friendship: %d\n", c.Friendship)
+	if c.Evolving != "" {
+		fmt.Fprintf(w, "Evolving into: %s\n", c.Evolving)
+	}
+	fmt.Fprintf(w, "Nature: %s\n", c.Nature)
+	fmt.Fprintf(w, "Gender: %s\n", c.Gender)
+	if c.Shiny {
+		fmt.Fprintln(w, "Shiny: yes")
+	}
+	fmt.Fprintf(w, "IVs: %s\n", statList(c.IVs))
+	fmt.Fprintf(w, "EVs: %s\n", statList(c.EVs))
+	fmt.Fprintf(w, "Caught: %s\n", c.caughtDescription())
+}
+
+func (c *CaughtPokemon) rows() [][]string {
+	return [][]string{
+		{"level", strconv.Itoa(c.Level)},
+		{"hp", c.healthDescription()},
+		{"moves", strings.Join(c.Moves, ", ")},
+		{"experience", strconv.Itoa(c.Experience)},
+		{"friendship", strconv.Itoa(c.Friendship)},
+		{"evolving", c.Evolving},
+		{"nature", c.Nature},
+		{"gender", c.Gender},
+		{"shiny", strconv.FormatBool(c.Shiny)},
+		{"ivs", statList(c.IVs)},
+		{"evs", statList(c.EVs)},
+		{"caught", c.caughtDescription()},
+	}
+}
+
+// healthDescription shows the Pokemon's HP with its status condition, or that
+// it fainted.
+func (c *CaughtPokemon) healthDescription() string {
+	switch {
+	case c.Fainted():
+		return "fainted"
+	case c.Status != "":
+		return fmt.Sprintf("%d (%s)", c.HP, c.Status)
+	}
+	return strconv.Itoa(c.HP)
+}
+
+func (c *CaughtPokemon) caughtDescription() string {
+	description := fmt.Sprintf("at level %d with a %s on %s", c.MetLevel, c.Ball, c.CaughtAt.Format(time.DateOnly))
+	if c.CaughtIn != "" {
+		description = "in " + c.CaughtIn + " " + description
+	}
+	return description
+}
+
+// statList formats per-stat values such as IVs in the API's stat order.
+func statList(values map[string]int) string {
+	parts := []string{}
+	for _, stat := range statNames {
+		parts = append(parts, fmt.Sprintf("%s %d", stat, values[stat]))
+	}
+	return strings.Join(parts, ", ")
+}
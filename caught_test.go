@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testCaught returns a caught Pikachu with the given ID.
+func testCaught(t *testing.T, id int) *CaughtPokemon {
+	caught := &CaughtPokemon{ID: id, Species: "pikachu", Level: 5}
+	if err := json.Unmarshal([]byte(testPokemon), &caught.Pokemon); err != nil {
+		t.Fatal(err)
+	}
+	return caught
+}
+
+// fullBox returns a box with every slot taken, starting at ID first.
+func fullBox(first int) []int {
+	box := make([]int, boxSize)
+	for i := range box {
+		box[i] = first + i
+	}
+	return box
+}
+
+func TestAddCaught(t *testing.T) {
+	withHole := fullBox(7)
+	withHole[2] = 0
+
+	cases := []struct {
+		party    []int
+		boxes    [][]int
+		expected slot
+		// boxCount is the number of boxes expected afterwards.
+		boxCount int
+	}{
+		{
+			party:    []int{},
+			expected: slot{index: 0},
+		},
+		{
+			party:    []int{1, 2, 3},
+			expected: slot{index: 3},
+		},
+		{
+			party:    []int{1, 2, 3, 4, 5, 6},
+			expected: slot{box: 1, index: 0},
+			boxCount: 1,
+		},
+		{
+			party:    []int{1, 2, 3, 4, 5, 6},
+			boxes:    [][]int{withHole},
+			expected: slot{box: 1, index: 2},
+			boxCount: 1,
+		},
+		{
+			party:    []int{1, 2, 3, 4, 5, 6},
+			boxes:    [][]int{fullBox(7)},
+			expected: slot{box: 2, index: 0},
+			boxCount: 2,
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			config := newTestConfig(t, 1)
+			config.Party = c.party
+			config.Boxes = c.boxes
+
+			caught := testCaught(t, 100)
+			actual := config.addCaught(caught)
+			if actual != c.expected {
+				t.Fatalf("Expected %+v, got %+v", c.expected, actual)
+			}
+			if len(config.Boxes) != c.boxCount {
+				t.Errorf("Expected %v boxes, got %v", c.boxCount, len(config.Boxes))
+			}
+			if s, ok := config.locate(caught.ID); !ok || s != c.expected {
+				t.Errorf("Expected to find it in %+v, got %+v", c.expected, s)
+			}
+			if config.Owned[caught.ID] != caught {
+				t.Errorf("Expected it to be owned")
+			}
+		})
+	}
+}
+
+func TestAddCaughtMarksSeen(t *testing.T) {
+	seenAt := time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC)
+	caughtAt := seenAt.Add(time.Hour)
+
+	cases := []struct {
+		// seenBy is how the Pokemon was seen before it was caught, if it was.
+		seenBy   string
+		expected DexEntry
+	}{
+		{
+			expected: DexEntry{Species: "pikachu", Number: 25, Caught: true, SeenAt: caughtAt, SeenIn: "viridian-forest-area", SeenBy: seenCatching},
+		},
+		{
+			// The first sighting is kept.
+			seenBy:   seenExploring,
+			expected: DexEntry{Species: "pikachu", Number: 25, Caught: true, SeenAt: seenAt, SeenIn: "viridian-forest-area", SeenBy: seenExploring},
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			now := seenAt
+			config := newTestConfig(t, 1)
+			config.Clock = func() time.Time { return now }
+			config.Area = "viridian-forest-area"
+
+			caught := testCaught(t, 1)
+			if c.seenBy != "" {
+				config.markSeen(caught.Pokemon, c.seenBy)
+			}
+			now = caughtAt
+			config.addCaught(caught)
+
+			actual := config.Pokedex["pikachu"]
+			if actual == nil || !reflect.DeepEqual(*actual, c.expected) {
+				t.Errorf("Expected %+v, got %+v", c.expected, actual)
+			}
+		})
+	}
+}
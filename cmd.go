@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"math/rand"
-	"os"
+	"pokedex/internal/stats"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -14,305 +16,535 @@ func getCommands() map[string]cliCommand {
 		"help": {
 			name:        "help",
 			description: "Displays a help message",
+			category:    categoryGeneral,
+			help:        "Without arguments lists every command by category. With a COMMAND shows its usage, flags and examples.",
+			args:        []argSpec{{name: "COMMAND", optional: true, complete: completeCommands}},
+			examples:    []string{"help", "help catch"},
 			callback:    commandHelp,
 		},
 		"exit": {
 			name:        "exit",
 			description: "Exit the Pokedex",
+			category:    categoryGeneral,
 			callback:    commandExit,
 		},
 		"map": {
 			name:        "map",
 			description: "Displays the names of the next 20 location areas in the Pokemon world.",
+			category:    categoryExploring,
+			help:        "Each call shows the next page of location areas. Use mapb to go back a page.",
 			callback:    commandMap,
 		},
 		"mapb": {
 			name:        "mapb",
 			description: "Displays the names of the previous 20 location areas in the Pokemon world.",
+			category:    categoryExploring,
 			callback:    commandMapb,
 		},
 		"explore": {
-			name:        "explore AREA-NAME",
+			name:        "explore",
 			description: "Displays the names of all pokemon in the given AREA-NAME argument.",
+			category:    categoryExploring,
+			help:        "Only Pokemon found in the selected game version are listed. You stay in the area, so you can catch the Pokemon found there.",
+			args:        []argSpec{{name: "AREA-NAME", complete: completeAreas}},
+			examples:    []string{"explore canalave-city-area"},
 			callback:    commandExplore,
+		}, "travel": {
+			name:        "travel",
+			description: "Go to the area in the AREA-NAME argument without exploring it.",
+			category:    categoryExploring,
+			args:        []argSpec{{name: "AREA-NAME", complete: completeAreas}},
+			examples:    []string{"travel viridian-forest-area"},
+			callback:    commandTravel,
+		}, "where": {
+			name:        "where",
+			description: "Show where and when you first saw the pokemon in the NAME argument.",
+			category:    categoryExploring,
+			help:        "Pokemon are seen when they are listed by explore or show up in an encounter. Along with the first sighting, where lists the areas your own Pokemon of the species were caught in.",
+			args:        []argSpec{{name: "NAME", complete: completeSeen}},
+			examples:    []string{"where pikachu"},
+			callback:    commandWhere,
+		}, "encounter": {
+			name:        "encounter",
+			description: "Look for a wild pokemon in the area you are in.",
+			category:    categoryExploring,
+			help:        "Which Pokemon shows up depends on its encounter chance, the method, the time of day and the season. It stays until it is caught or flees.",
+			flags:       []flagSpec{{name: "method", value: "METHOD", def: methodWalk, usage: "how to look: walk, surf, old-rod, good-rod, super-rod, rock-smash, ...", lower: true}},
+			examples:    []string{"encounter", "encounter --method surf"},
+			callback:    commandEncounter,
 		}, "catch": {
-			name:        "catch POKEMON-NAME",
+			name:        "catch",
 			description: "Attempt to catch the pokemon in the POKEMON-NAME argument.",
-			callback:    commandCatch,
+			category:    categoryPokemon,
+			help:        "After an encounter the wild Pokemon is the only one that can be caught, and it may flee when it breaks free. Otherwise only Pokemon found in the area you are in can be caught, and they may not show up every time you look: the odds are those of one game, the selected version or a random one, and of one encounter method. The chance to catch depends on the species' capture rate, the ball from your bag and the target's HP and status.",
+			args:        []argSpec{{name: "POKEMON-NAME", optional: true, complete: completeExplored}},
+			flags: []flagSpec{
+				{name: "ball", value: "BALL", def: "poke-ball", usage: "the ball to throw", lower: true},
+				{name: "method", value: "METHOD", def: methodWalk, usage: "how to look for the pokemon: walk, surf, old-rod, ...", lower: true},
+			},
+			examples: []string{"catch pikachu", "catch pikachu --ball ultra-ball", "catch magikarp --method old-rod", "catch --ball great-ball"},
+			callback: commandCatch,
 		}, "inspect": {
-			name:        "inspect POKEMON-NAME",
-			description: "View information of the pokemon in the POKEMON-NAME argument.",
+			name:        "inspect",
+			description: "View information of your pokemon with the ID or name in the POKEMON argument.",
+			category:    categoryPokemon,
+			help:        "Shows the level, nature, gender, IVs and EVs of a Pokemon you have caught, with the height, weight, types and abilities of its kind and its species entry. Each stat is shown as calculated from the base stat, level, IVs, EVs and nature, with the range the stat can have at --level. When you own several Pokemon of that name the one caught first is shown; pass its ID to pick another.",
+			args:        []argSpec{{name: "POKEMON", complete: completeCaught}},
+			flags:       []flagSpec{{name: "level", kind: flagInt, value: "LEVEL", def: "100", usage: "level to show stat ranges at"}},
+			examples:    []string{"inspect pikachu", "inspect 3 --level 50"},
 			callback:    commandInspect,
+		}, "species": {
+			name:        "species",
+			description: "View the Pokedex entry of the species in the SPECIES-NAME argument.",
+			category:    categoryPokemon,
+			help:        "Shows the genus, flavor text, generation, capture rate, base happiness, growth rate and egg groups of any species, caught or not.",
+			args:        []argSpec{{name: "SPECIES-NAME", complete: completePokemon}},
+			examples:    []string{"species pikachu"},
+			callback:    commandSpecies,
+		}, "evolution": {
+			name:        "evolution",
+			description: "Show the evolution chain of the species in the SPECIES-NAME argument.",
+			category:    categoryPokemon,
+			help:        "Draws every branch of the chain with what triggers each evolution. Species you have caught are marked with *.",
+			args:        []argSpec{{name: "SPECIES-NAME", complete: completePokemon}},
+			examples:    []string{"evolution eevee", "evolution pikachu"},
+			callback:    commandEvolution,
+		}, "moves": {
+			name:        "moves",
+			description: "List the moves the pokemon in the POKEMON-NAME argument can learn.",
+			category:    categoryPokemon,
+			help:        "Level-up moves are sorted by the level they are learned at. Without --version-group the newest game the Pokemon appears in is used. Pass --method all to include every learn method.",
+			args:        []argSpec{{name: "POKEMON-NAME", complete: completePokemon}},
+			flags: []flagSpec{
+				{name: "version-group", value: "VERSION-GROUP", usage: "game to list the learnset for, e.g. red-blue or scarlet-violet", lower: true},
+				{name: "method", value: "METHOD", def: "level-up", usage: "learn method: level-up, machine, egg, tutor or all", lower: true},
+			},
+			examples: []string{"moves pikachu", "moves pikachu --version-group red-blue", "moves pikachu --method machine"},
+			callback: commandMoves,
+		}, "move": {
+			name:        "move",
+			description: "View the details of the move in the MOVE-NAME argument.",
+			category:    categoryPokemon,
+			help:        "Shows the type, power, accuracy, PP, damage class and effect of a move.",
+			args:        []argSpec{{name: "MOVE-NAME", complete: completeMoves}},
+			examples:    []string{"move thunderbolt"},
+			callback:    commandMove,
+		}, "ability": {
+			name:        "ability",
+			description: "View the effect of the ability in the ABILITY-NAME argument and the pokemon that can have it.",
+			category:    categoryPokemon,
+			help:        "Pokemon that only get the ability as a hidden ability are marked (hidden), and those in your Pokedex are marked with *.",
+			args:        []argSpec{{name: "ABILITY-NAME", complete: completeAbilities}},
+			examples:    []string{"ability static"},
+			callback:    commandAbility,
+		}, "type": {
+			name:        "type",
+			description: "Show what the type in the TYPE-NAME argument is strong and weak against.",
+			category:    categoryTypes,
+			args:        []argSpec{{name: "TYPE-NAME", complete: completeTypes}},
+			examples:    []string{"type electric"},
+			callback:    commandType,
+		}, "weakness": {
+			name:        "weakness",
+			description: "Show how much damage each type deals to the pokemon in the POKEMON-NAME argument.",
+			category:    categoryTypes,
+			help:        "Combines both types of dual-type Pokemon, so ice against a dragon/flying Pokemon shows as 4x. Types that deal normal damage are left out.",
+			args:        []argSpec{{name: "POKEMON-NAME", complete: completePokemon}},
+			examples:    []string{"weakness gyarados"},
+			callback:    commandWeakness,
+		}, "matchup": {
+			name:        "matchup",
+			description: "Compare how the ATTACKER and DEFENDER pokemon or types damage each other.",
+			category:    categoryTypes,
+			args:        []argSpec{{name: "ATTACKER", complete: completeMatchup}, {name: "DEFENDER", complete: completeMatchup}},
+			examples:    []string{"matchup pikachu squirtle", "matchup ice dragonite"},
+			callback:    commandMatchup,
+		}, "battle": {
+			name:        "battle",
+			description: "Fight the wild pokemon met with encounter.",
+			category:    categoryBattle,
+			help:        "The first Pokemon in your party that can fight is sent out. Each turn the faster Pokemon moves first, unless a move has priority. Damage depends on the moves' power and type, same-type attack bonus, type effectiveness and critical hits. A weakened Pokemon, or one with a status condition, is easier to catch. HP and status carry over after the battle.",
+			examples:    []string{"battle"},
+			callback:    commandBattle,
+		}, "fight": {
+			name:        "fight",
+			description: "Attack the wild pokemon with the move in the MOVE argument.",
+			category:    categoryBattle,
+			args:        []argSpec{{name: "MOVE", complete: completeFight}},
+			examples:    []string{"fight thunder-shock"},
+			callback:    commandFight,
+		}, "switch": {
+			name:        "switch",
+			description: "Send out your party pokemon with the ID in the ID argument.",
+			category:    categoryBattle,
+			help:        "Switching takes your turn, unless the Pokemon that was out has fainted.",
+			args:        []argSpec{{name: "ID", complete: completeCaught}},
+			examples:    []string{"switch 2"},
+			callback:    commandSwitch,
+		}, "learn": {
+			name:        "learn",
+			description: "List or learn the moves your pokemon in the POKEMON argument wants to learn.",
+			category:    categoryBattle,
+			help:        "Pokemon earn experience for defeating and catching wild Pokemon, and learn new moves as they level up. A Pokemon that already knows 4 moves waits for you to pick one to forget with --forget, or to give up the new move with --skip.",
+			args:        []argSpec{{name: "POKEMON", complete: completeLearn}, {name: "MOVE", optional: true, complete: completeLearn}},
+			flags: []flagSpec{
+				{name: "forget", value: "MOVE", usage: "the move to forget for the new one", lower: true},
+				{name: "skip", kind: flagBool, usage: "don't learn the new move"},
+			},
+			examples: []string{"learn 1", "learn 1 thunderbolt --forget growl", "learn 1 thunderbolt --skip"},
+			callback: commandLearn,
+		}, "evolve": {
+			name:        "evolve",
+			description: "Let your evolving pokemon in the POKEMON argument evolve, or stop it with --cancel.",
+			category:    categoryPokemon,
+			help:        "A Pokemon that meets the level, friendship, time of day or other conditions of an evolution when it levels up starts evolving. It keeps its nickname, level, IVs, EVs and moves. A canceled evolution is tried again at the next level-up. Evolution stones evolve a Pokemon right away with use, and trade evolutions happen with trade.",
+			args:        []argSpec{{name: "POKEMON", complete: completeEvolving}},
+			flags:       []flagSpec{{name: "cancel", kind: flagBool, usage: "stop the evolution"}},
+			examples:    []string{"evolve 1", "evolve 1 --cancel"},
+			callback:    commandEvolve,
+		}, "trade": {
+			name:        "trade",
+			description: "Trade your pokemon in the POKEMON argument with a friend and get it back.",
+			category:    categoryPokemon,
+			help:        "Pokemon that evolve when traded evolve on the way.",
+			args:        []argSpec{{name: "POKEMON", complete: completeCaught}},
+			examples:    []string{"trade kadabra"},
+			callback:    commandTrade,
+		}, "heal": {
+			name:        "heal",
+			description: "Heal your party at a Pokemon Center.",
+			category:    categoryBattle,
+			help:        "Restores the HP of every Pokemon in your party, fainted or not, and cures their status conditions.",
+			callback:    commandHeal,
 		}, "pokedex": {
 			name:        "pokedex",
-			description: "List all the pokemon you've caught.",
+			description: "List all the species you've seen or caught with the IDs of your pokemon.",
+			category:    categoryPokemon,
+			help:        "Species are listed by their national Pokedex number. Species you have seen, by exploring an area or meeting them in an encounter, but not caught yet are marked (seen); where tells you where you saw them. With --dex the Pokedex shows how complete you are against an official Pokedex, such as kanto, original-johto or national: how many of its species you have caught and seen, and every entry in order with the ones you are missing marked ---.",
+			flags:       []flagSpec{{name: "dex", value: "POKEDEX", usage: "official Pokedex to track completion against, e.g. kanto or national", lower: true}},
+			examples:    []string{"pokedex", "pokedex --dex kanto"},
 			callback:    commandPokedex,
+		}, "party": {
+			name:        "party",
+			description: "List or change the pokemon in your party.",
+			category:    categoryPokemon,
+			help:        "Your party holds up to 6 Pokemon; the rest are kept in boxes. New catches join the party when there is room. \"party add\" takes a Pokemon out of its box, \"party remove\" sends one to the first free box slot, and \"party swap\" swaps the places of two Pokemon in the party or boxes.",
+			args:        []argSpec{{name: "add|remove|swap", optional: true, complete: completePartyArgs}, {name: "ID", optional: true, variadic: true, complete: completePartyArgs}},
+			examples:    []string{"party", "party add 7", "party remove 2", "party swap 1 7"},
+			callback:    commandParty,
+		}, "box": {
+			name:        "box",
+			description: "List the pokemon in box N, or the first box.",
+			category:    categoryPokemon,
+			args:        []argSpec{{name: "N", optional: true, complete: completeBoxes}},
+			examples:    []string{"box", "box 2"},
+			callback:    commandBox,
+		}, "release": {
+			name:        "release",
+			description: "Release your pokemon with the ID in the ID argument.",
+			category:    categoryPokemon,
+			args:        []argSpec{{name: "ID", complete: completeCaught}},
+			examples:    []string{"release 4"},
+			callback:    commandRelease,
+		}, "nickname": {
+			name:        "nickname",
+			description: "Give your pokemon with the ID in the ID argument a nickname.",
+			category:    categoryPokemon,
+			help:        "Nicknames can be up to 12 characters long. Leave out NAME to remove the nickname.",
+			args:        []argSpec{{name: "ID", complete: completeCaught}, {name: "NAME", optional: true, raw: true}},
+			examples:    []string{"nickname 1 Sparky", "nickname 1"},
+			callback:    commandNickname,
+		}, "bag": {
+			name:        "bag",
+			description: "List the items in your bag.",
+			category:    categoryItems,
+			callback:    commandBag,
+		}, "item": {
+			name:        "item",
+			description: "View information of the item in the ITEM-NAME argument.",
+			category:    categoryItems,
+			args:        []argSpec{{name: "ITEM-NAME", complete: completeBag}},
+			examples:    []string{"item potion"},
+			callback:    commandItem,
+		}, "use": {
+			name:        "use",
+			description: "Use the item in the ITEM-NAME argument from your bag.",
+			category:    categoryItems,
+			help:        "Poke Balls are thrown at POKEMON-NAME, evolution stones evolve a caught Pokemon that reacts to them, and medicine and berries heal a caught Pokemon or cure its status. The item is used up. In a battle, using an item takes your turn.",
+			args:        []argSpec{{name: "ITEM-NAME", complete: completeBag}, {name: "POKEMON-NAME", optional: true, complete: completePokemon}},
+			examples:    []string{"use great-ball pikachu", "use thunder-stone pikachu", "use potion 1"},
+			callback:    commandUse,
+		}, "set": {
+			name:        "set",
+			description: "Change a setting, e.g. \"set output json\".",
+			category:    categoryGeneral,
+			help:        "Settings:\n  output   how results are printed: plain, table, json or yaml\n  version  the game version encounters come from, e.g. red or diamond, or any\n  seed     restart random outcomes from a number, to replay them",
+			args:        []argSpec{{name: "SETTING", complete: completeSettings}, {name: "VALUE", complete: completeSettingValues}},
+			examples:    []string{"set output json", "set seed 42"},
+			callback:    commandSet,
+		}, "run": {
+			name:        "run",
+			description: "Run the commands in FILE, one per line, or run from a battle.",
+			category:    categoryScripting,
+			help:        "Blank lines and lines starting with # are skipped. The script stops at the first failing command unless --continue is passed or the Pokedex was started with it, and exit in a script closes the Pokedex. In a battle, run without FILE tries to get away; the faster your Pokemon, the likelier that is.",
+			args:        []argSpec{{name: "FILE", optional: true, raw: true}},
+			flags: []flagSpec{
+				{name: "continue", kind: flagBool, usage: "keep running after a command fails"},
+				{name: "echo", kind: flagBool, usage: "print each command before running it"},
+			},
+			examples: []string{"run catch-them-all.txt", "run catch-them-all.txt --continue --echo", "run"},
+			callback: commandRun,
+		}, "alias": {
+			name:        "alias",
+			description: "List aliases, or define NAME as a shortcut for a command.",
+			category:    categoryScripting,
+			help:        "Any arguments given to an alias are added to the end of its command. Quote several commands separated by \";\" to define a macro. Aliases are saved in your config file and cannot replace built-in commands.",
+			args:        []argSpec{{name: "NAME", optional: true, complete: completeAliases}, {name: "COMMAND", optional: true, variadic: true, raw: true}},
+			examples:    []string{"alias", "alias c catch", "alias grind \"explore mt-coronet-1f; catch geodude\""},
+			callback:    commandAlias,
+		}, "unalias": {
+			name:        "unalias",
+			description: "Remove the alias NAME.",
+			category:    categoryScripting,
+			args:        []argSpec{{name: "NAME", complete: completeAliases}},
+			callback:    commandUnalias,
 		},
 	}
 }
 
-func parseCommand(command string) (func(*Config, string) error, string, error) {
-	args := strings.Split(command, " ")
-
-	cmd, ok := commands[args[0]]
-
-	if len(args) == 1 {
-		args = append(args, "")
-	}
-
-	if ok {
-		return cmd.callback, args[1], nil
-	}
-	return nil, "", errors.New("no such command")
-}
-
-func commandHelp(config *Config, arg string) error {
-	fmt.Println("\nWelcome to the Pokedex!\nUsage:")
-	fmt.Println()
-
-	for _, command := range commands {
-		fmt.Printf("%s: %s\n", command.name, command.description)
+func parseCommand(tokens []string) (cliCommand, cmdArgs, error) {
+	cmd, ok := commands[strings.ToLower(tokens[0])]
+	if !ok {
+		return cliCommand{}, cmdArgs{}, errNoCommand
 	}
 
-	fmt.Println("")
-
-	return nil
+	args, err := parseArgs(cmd, tokens[1:])
+	return cmd, args, err
 }
 
-func commandExit(config *Config, arg string) error {
-	fmt.Println("Closing the Pokedex... Goodbye!")
-	os.Exit(0)
-	return nil
+func commandExit(config *Config, args cmdArgs) (any, error) {
+	return message{Message: "Closing the Pokedex... Goodbye!"}, errExit
 }
 
-func commandMap(config *Config, arg string) error {
+func commandMap(config *Config, args cmdArgs) (any, error) {
 	var err error
 
 	body, ok := config.Cache.Get(config.Next)
 	if !ok {
 		body, err = httpGet(config.Next)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		err = config.Cache.Add(config.Next, body)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	result := mapResult{}
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return err
+		return nil, err
 
 	}
 
-	fmt.Println()
-
-	for _, item := range result.Results {
-		fmt.Println(item.Name)
-	}
-
-	fmt.Println()
-
 	config.Next = result.Next
 	config.Previous = result.Previous
 
-	return nil
+	list := newLocationList(result)
+	config.LastAreas = list.Locations
+
+	return list, nil
 }
 
-func commandMapb(config *Config, arg string) error {
+func commandMapb(config *Config, args cmdArgs) (any, error) {
 	var err error
 
 	if config.Previous == "" {
-		return errors.New(`already at the beginning of the map`)
+		return nil, errors.New(`already at the beginning of the map`)
 	}
 	body, ok := config.Cache.Get(config.Previous)
 	if !ok {
 		body, err = httpGet(config.Previous)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		err = config.Cache.Add(config.Previous, body)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	result := mapResult{}
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return err
-
-	}
-
-	fmt.Println()
+		return nil, err
 
-	for _, item := range result.Results {
-		fmt.Println(item.Name)
 	}
 
-	fmt.Println()
-
 	config.Next = result.Next
 	config.Previous = result.Previous
 
-	return nil
+	list := newLocationList(result)
+	config.LastAreas = list.Locations
+
+	return list, nil
 }
 
-func commandExplore(config *Config, arg string) error {
-	var err error
+func commandExplore(config *Config, args cmdArgs) (any, error) {
+	if config.Battle != nil {
+		return nil, errInBattle
+	}
+
+	arg := args.arg(0)
 
-	if arg == "" {
-		return errors.New("no area specified.")
+	result, err := config.getArea(arg)
+	if err != nil {
+		return nil, err
 	}
 
-	body, ok := config.Cache.Get(config.Explore + arg)
+	explored := exploreResult{Area: arg, Pokemon: []string{}}
 
-	if !ok {
-		body, err = httpGet(config.Explore + arg)
-		if err != nil {
-			if strings.Contains(err.Error(), "404") {
-				return errors.New("invalid area name.")
-			} else {
-				return err
-			}
+	for _, encounter := range config.areaEncounters(result) {
+		if !slices.Contains(explored.Pokemon, encounter.Pokemon) {
+			explored.Pokemon = append(explored.Pokemon, encounter.Pokemon)
 		}
+	}
 
-		err = config.Cache.Add(config.Explore+arg, body)
+	// Only Pokemon of species not seen yet are looked up, most of them are
+	// named after their species. They are looked up before the player
+	// moves, so a failed lookup leaves everything as it was.
+	unseen := []Pokemon{}
+	for _, name := range explored.Pokemon {
+		if _, ok := config.Pokedex[name]; ok {
+			continue
+		}
+		pokemon, err := config.getPokemon(name)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		unseen = append(unseen, pokemon)
 	}
 
-	result := Explore{}
-
-	err = json.Unmarshal(body, &result)
-	if err != nil {
-		return err
+	config.Area = result.Name
+	config.Wild = nil
 
+	for _, pokemon := range unseen {
+		config.markSeen(pokemon, seenExploring)
 	}
 
-	fmt.Println()
-	fmt.Println("Exploring " + arg + "...")
-	fmt.Println("Found Pokemon:")
-	fmt.Println()
-
-	for _, encounter := range result.PokemonEncounters {
-		fmt.Println(" - " + encounter.Pokemon.Name)
-	}
+	explored.Found = config.findItem()
 
-	fmt.Println()
+	config.LastExplored = explored.Pokemon
 
-	return nil
+	return explored, nil
 }
 
-func commandCatch(config *Config, arg string) error {
-	var err error
+func commandCatch(config *Config, args cmdArgs) (any, error) {
+	return config.catchWild(args.arg(0), args.flag("ball"), args.flag("method"))
+}
 
-	if arg == "" {
-		return errors.New("no pokemon specified.")
+func commandInspect(config *Config, args cmdArgs) (any, error) {
+	level := args.intFlag("level")
+	if level < 1 || level > stats.MaxLevel {
+		return nil, usageError{commands["inspect"], fmt.Sprintf("--level must be between 1 and %d", stats.MaxLevel)}
 	}
 
-	body, ok := config.Cache.Get(config.Pokemon + arg)
-
-	if !ok {
-		body, err = httpGet(config.Pokemon + arg)
-		if err != nil {
-			if strings.Contains(err.Error(), "404") {
-				return errors.New("invalid pokemon name.")
-			} else {
-				return err
-			}
-		}
-
-		err = config.Cache.Add(config.Pokemon+arg, body)
-		if err != nil {
-			return err
-		}
+	caught, err := config.findOwned(args.arg(0))
+	if err != nil {
+		return nil, err
 	}
 
-	result := Pokemon{}
-
-	err = json.Unmarshal(body, &result)
+	species, err := config.getSpeciesOf(caught.Pokemon)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	//Get change based on base experience, basically 75/basexp chance
-	fmt.Println()
-	fmt.Printf("Throwinga Pokeball at %s\n", result.Name)
-
-	denom := result.BaseExperience / 75
-
-	if denom < 1 {
-		denom = 1
+	values, err := config.caughtStats(caught)
+	if err != nil {
+		return nil, err
 	}
 
-	rand := rand.Intn(denom + 1)
+	info := newPokemonInfo(caught.Pokemon)
+	speciesInfo := newSpeciesInfo(species)
+	info.Species = &speciesInfo
+	info.Instance = caught
+	info.RangeLevel = level
 
-	if rand == 1 {
-		config.Pokedex[result.Name] = result
-		fmt.Printf("%s was caught!\n", result.Name)
-		fmt.Println("you may now inspect it with the inspect command.")
-	} else {
-		fmt.Printf("%s escaped!\n", result.Name)
+	for i, stat := range info.Stats {
+		info.Stats[i].Value = values[stat.Name]
+		info.Stats[i].Min, info.Stats[i].Max = stats.Range(stat.Name, stat.BaseStat, info.RangeLevel)
 	}
 
-	fmt.Println()
-
-	return nil
+	return info, nil
 }
 
-func commandInspect(config *Config, arg string) error {
-	pokemon, ok := config.Pokedex[arg]
-
-	if !ok {
-		fmt.Println("you have not caught that pokemon.")
-		fmt.Println()
-		return nil
+func commandPokedex(config *Config, args cmdArgs) (any, error) {
+	if dex := args.flag("dex"); dex != "" {
+		result, err := config.dexCompletion(dex)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
 	}
 
-	fmt.Println()
-	fmt.Printf("Name: %s\n", pokemon.Name)
-	fmt.Printf("Height: %d\n", pokemon.Height)
-	fmt.Printf("Weight: %d\n", pokemon.Weight)
-	fmt.Println("Stats:")
+	result := pokedexResult{Pokemon: []pokedexEntry{}}
 
-	for _, stat := range pokemon.Stats {
-		fmt.Printf("   -%s: %d\n", stat.Stat.Name, stat.BaseStat)
+	for _, entry := range config.Pokedex {
+		status := dexSeen
+		if entry.Caught {
+			status = dexCaught
+		}
+		result.Pokemon = append(result.Pokemon, pokedexEntry{Number: entry.Number, Species: entry.Species, Status: status, Owned: []int{}})
 	}
 
-	fmt.Println("Types:")
+	sort.Slice(result.Pokemon, func(i, j int) bool {
+		a, b := result.Pokemon[i], result.Pokemon[j]
+		if a.Number != b.Number {
+			return a.Number < b.Number
+		}
+		return a.Species < b.Species
+	})
 
-	for _, types := range pokemon.Types {
-		fmt.Printf("   - %s\n", types.Type.Name)
+	for _, id := range config.ownedIDs() {
+		for i := range result.Pokemon {
+			if result.Pokemon[i].Species == config.Owned[id].Species {
+				result.Pokemon[i].Owned = append(result.Pokemon[i].Owned, id)
+			}
+		}
 	}
 
-	fmt.Println()
-
-	return nil
+	return result, nil
 }
 
-func commandPokedex(config *Config, arg string) error {
+func commandSet(config *Config, args cmdArgs) (any, error) {
+	setting, value := args.arg(0), args.arg(1)
 
-	fmt.Println()
-
-	if len(config.Pokedex) == 0 {
-		fmt.Println("you have not caught any pokemon.")
-		fmt.Println()
-		return nil
-	}
-
-	fmt.Println("Your Pokedex:")
-
-	for _, pokemon := range config.Pokedex {
-		fmt.Printf("   - %s\n", pokemon.Name)
+	switch setting {
+	case "output":
+		if !validOutputFormat(value) {
+			return nil, fmt.Errorf("unknown output format %q, expected one of: %s", value, strings.Join(outputFormats, ", "))
+		}
+		config.Output = value
+	case "version":
+		if value != anyVersion {
+			err := config.getVersion(value)
+			if err != nil {
+				return nil, err
+			}
+		}
+		config.GameVersion = value
+	case "seed":
+		seed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("seed must be a whole number, got %q", value)
+		}
+		config.reseed(seed)
+	default:
+		return nil, fmt.Errorf("unknown setting %q", setting)
 	}
 
-	fmt.Println()
-
-	return nil
+	return message{Message: fmt.Sprintf("%s set to %s", setting, value)}, nil
 }
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// complete returns tab completion candidates for the word at the end of
+// before: command names for the first word, flags for words starting with
+// "--", and otherwise whatever the command's argument spec offers.
+func (config *Config) complete(before string) []string {
+	tokens, err := tokenize(before)
+	if err != nil {
+		return nil
+	}
+
+	atNewWord := before == "" || unicode.IsSpace(rune(before[len(before)-1]))
+	if atNewWord {
+		tokens = append(tokens, "")
+	}
+
+	if len(tokens) <= 1 {
+		return completeCommands(config, nil)
+	}
+
+	cmd, ok := commands[strings.ToLower(tokens[0])]
+	if !ok {
+		return nil
+	}
+
+	word := tokens[len(tokens)-1]
+	if strings.HasPrefix(word, "--") {
+		candidates := []string{}
+		for _, f := range cmd.flags {
+			candidates = append(candidates, "--"+f.name)
+		}
+		return candidates
+	}
+
+	positional := []string{}
+	prior := tokens[1 : len(tokens)-1]
+	for i := 0; i < len(prior); i++ {
+		name, isFlag := strings.CutPrefix(prior[i], "--")
+		if !isFlag {
+			positional = append(positional, strings.ToLower(prior[i]))
+			continue
+		}
+		if f, ok := cmd.flag(name); ok && f.kind != flagBool {
+			i++
+		}
+	}
+
+	spec, ok := cmd.argAt(len(positional))
+	if !ok || spec.complete == nil {
+		return nil
+	}
+
+	return spec.complete(config, positional)
+}
+
+// argAt returns the spec of the i-th positional argument, repeating a
+// trailing variadic argument.
+func (c cliCommand) argAt(i int) (argSpec, bool) {
+	if i < len(c.args) {
+		return c.args[i], true
+	}
+	if len(c.args) > 0 && c.args[len(c.args)-1].variadic {
+		return c.args[len(c.args)-1], true
+	}
+	return argSpec{}, false
+}
+
+func commandNames() []string {
+	names := []string{}
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func completeCommands(config *Config, before []string) []string {
+	return append(commandNames(), completeAliases(config, nil)...)
+}
+
+func completeAreas(config *Config, before []string) []string {
+	return config.LastAreas
+}
+
+func completeExplored(config *Config, before []string) []string {
+	return config.LastExplored
+}
+
+// completeCaught offers the IDs and names of the player's Pokemon.
+func completeCaught(config *Config, before []string) []string {
+	names := []string{}
+	for id, caught := range config.Owned {
+		names = append(names, strconv.Itoa(id), caught.Pokemon.Name)
+	}
+	return names
+}
+
+// completePokemon offers every Pokemon name the player has come across.
+func completePokemon(config *Config, before []string) []string {
+	return append(completeCaught(config, before), config.LastExplored...)
+}
+
+// completeSeen offers the species in the player's Pokedex.
+func completeSeen(config *Config, before []string) []string {
+	names := []string{}
+	for species := range config.Pokedex {
+		names = append(names, species)
+	}
+	return names
+}
+
+// completeMoves offers the moves caught Pokemon can learn.
+func completeMoves(config *Config, before []string) []string {
+	names := []string{}
+	for _, caught := range config.Owned {
+		for _, move := range caught.Pokemon.Moves {
+			names = append(names, move.Move.Name)
+		}
+	}
+	return names
+}
+
+// completeAbilities offers the abilities of caught Pokemon.
+func completeAbilities(config *Config, before []string) []string {
+	names := []string{}
+	for _, caught := range config.Owned {
+		for _, ability := range caught.Pokemon.Abilities {
+			names = append(names, ability.Ability.Name)
+		}
+	}
+	return names
+}
+
+var settings = []string{"output", "version", "seed"}
+
+func completeSettings(config *Config, before []string) []string {
+	return settings
+}
+
+func completeSettingValues(config *Config, before []string) []string {
+	switch before[0] {
+	case "output":
+		return outputFormats
+	}
+	return nil
+}
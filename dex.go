@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Completion states of an entry in an official Pokedex.
+const (
+	dexCaught  = "caught"
+	dexSeen    = "seen"
+	dexMissing = "missing"
+)
+
+type dexSlot struct {
+	Number  int    `json:"number"`
+	Species string `json:"species"`
+	Status  string `json:"status"`
+}
+
+type dexCompletionResult struct {
+	Dex           string    `json:"dex"`
+	Total         int       `json:"total"`
+	Caught        int       `json:"caught"`
+	Seen          int       `json:"seen"`
+	Missing       int       `json:"missing"`
+	CaughtPercent float64   `json:"caught_percent"`
+	SeenPercent   float64   `json:"seen_percent"`
+	Entries       []dexSlot `json:"entries"`
+}
+
+func (r dexCompletionResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s Pokedex:\n", r.Dex)
+	fmt.Fprintf(w, "Caught:  %d/%d (%.1f%%)\n", r.Caught, r.Total, r.CaughtPercent)
+	fmt.Fprintf(w, "Seen:    %d/%d (%.1f%%)\n", r.Seen, r.Total, r.SeenPercent)
+	fmt.Fprintf(w, "Missing: %d\n", r.Missing)
+	fmt.Fprintln(w)
+
+	for _, entry := range r.Entries {
+		switch entry.Status {
+		case dexCaught:
+			fmt.Fprintf(w, "   #%03d %s *\n", entry.Number, entry.Species)
+		case dexSeen:
+			fmt.Fprintf(w, "   #%03d %s\n", entry.Number, entry.Species)
+		default:
+			fmt.Fprintf(w, "   #%03d ---\n", entry.Number)
+		}
+	}
+
+	fmt.Fprintln(w)
+}
+
+func (r dexCompletionResult) tableRows() ([]string, [][]string) {
+	rows := [][]string{}
+	for _, entry := range r.Entries {
+		rows = append(rows, []string{strconv.Itoa(entry.Number), entry.Species, entry.Status})
+	}
+	return []string{"NUMBER", "SPECIES", "STATUS"}, rows
+}
+
+// dexCompletion compares the player's Pokedex with an official one. Seen
+// counts every species seen, including the caught ones.
+func (config *Config) dexCompletion(name string) (dexCompletionResult, error) {
+	dex, err := config.getRegionalDex(name)
+	if err != nil {
+		return dexCompletionResult{}, err
+	}
+
+	result := dexCompletionResult{Dex: dex.Name, Total: len(dex.PokemonEntries), Entries: []dexSlot{}}
+
+	for _, entry := range dex.PokemonEntries {
+		slot := dexSlot{Number: entry.EntryNumber, Species: entry.PokemonSpecies.Name, Status: dexMissing}
+
+		if seen, ok := config.Pokedex[slot.Species]; ok {
+			slot.Status = dexSeen
+			result.Seen++
+			if seen.Caught {
+				slot.Status = dexCaught
+				result.Caught++
+			}
+		}
+
+		result.Entries = append(result.Entries, slot)
+	}
+
+	sort.Slice(result.Entries, func(i, j int) bool {
+		return result.Entries[i].Number < result.Entries[j].Number
+	})
+
+	result.Missing = result.Total - result.Seen
+	if result.Total > 0 {
+		result.CaughtPercent = percent(result.Caught, result.Total)
+		result.SeenPercent = percent(result.Seen, result.Total)
+	}
+
+	return result, nil
+}
+
+// percent returns part of total in percent, rounded to one decimal.
+func percent(part int, total int) float64 {
+	return math.Round(float64(part)*1000/float64(total)) / 10
+}
+
+type whereResult struct {
+	Species string    `json:"species"`
+	Number  int       `json:"number"`
+	Status  string    `json:"status"`
+	SeenAt  time.Time `json:"seen_at"`
+	SeenIn  string    `json:"seen_in,omitempty"`
+	SeenBy  string    `json:"seen_by,omitempty"`
+	// CaughtIn are the areas the player's Pokemon of the species were
+	// caught in.
+	CaughtIn []string `json:"caught_in"`
+}
+
+func (r whereResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s (#%03d) %s.\n", r.Species, r.Number, r.describeSighting())
+
+	switch {
+	case r.Status != dexCaught:
+		fmt.Fprintln(w, "You have not caught one yet.")
+	case len(r.CaughtIn) > 0:
+		fmt.Fprintf(w, "You caught yours in: %s\n", strings.Join(r.CaughtIn, ", "))
+	}
+
+	fmt.Fprintln(w)
+}
+
+func (r whereResult) tableRows() ([]string, [][]string) {
+	rows := [][]string{
+		{"species", r.Species},
+		{"number", strconv.Itoa(r.Number)},
+		{"status", r.Status},
+		{"seen in", r.SeenIn},
+		{"seen by", r.SeenBy},
+		{"seen at", r.SeenAt.Format(time.DateTime)},
+	}
+	for _, area := range r.CaughtIn {
+		rows = append(rows, []string{"caught in", area})
+	}
+	return []string{"FIELD", "VALUE"}, rows
+}
+
+// describeSighting tells where, how and when the species was first seen.
+func (r whereResult) describeSighting() string {
+	description := "was first seen"
+	if r.SeenIn != "" {
+		description += " in " + r.SeenIn
+	}
+	switch r.SeenBy {
+	case "":
+	case seenExploring:
+		description += " while exploring"
+	case seenCatching:
+		description += " when you caught one"
+	case seenEvolving:
+		description += " when one of your pokemon evolved into it"
+	default:
+		description += " in an encounter (" + r.SeenBy + ")"
+	}
+	return description + " on " + r.SeenAt.Format(time.DateTime)
+}
+
+func commandWhere(config *Config, args cmdArgs) (any, error) {
+	name := args.arg(0)
+
+	entry, ok := config.Pokedex[name]
+	if !ok {
+		// The name may be a form or a species not seen yet.
+		species, err := config.getSpecies(name)
+		if err != nil {
+			return nil, err
+		}
+		entry, ok = config.Pokedex[species.Name]
+		if !ok {
+			return nil, fmt.Errorf("you have not seen %s yet.", species.Name)
+		}
+	}
+
+	result := whereResult{
+		Species:  entry.Species,
+		Number:   entry.Number,
+		Status:   dexSeen,
+		SeenAt:   entry.SeenAt,
+		SeenIn:   entry.SeenIn,
+		SeenBy:   entry.SeenBy,
+		CaughtIn: []string{},
+	}
+	if entry.Caught {
+		result.Status = dexCaught
+	}
+
+	for _, id := range config.ownedIDs() {
+		caught := config.Owned[id]
+		if caught.Species == entry.Species && caught.CaughtIn != "" && !slices.Contains(result.CaughtIn, caught.CaughtIn) {
+			result.CaughtIn = append(result.CaughtIn, caught.CaughtIn)
+		}
+	}
+
+	return result, nil
+}
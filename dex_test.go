@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDexCompletion(t *testing.T) {
+	// Entries are listed out of order, the way they may come from the API.
+	const kanto = `{"name": "kanto", "pokemon_entries": [
+		{"entry_number": 4, "pokemon_species": {"name": "charmander"}},
+		{"entry_number": 1, "pokemon_species": {"name": "bulbasaur"}},
+		{"entry_number": 25, "pokemon_species": {"name": "pikachu"}},
+		{"entry_number": 7, "pokemon_species": {"name": "squirtle"}}
+	]}`
+
+	cases := []struct {
+		pokedex  map[string]*DexEntry
+		dex      string
+		expected dexCompletionResult
+	}{
+		{
+			pokedex: map[string]*DexEntry{},
+			dex:     kanto,
+			expected: dexCompletionResult{
+				Dex: "kanto", Total: 4, Missing: 4,
+				Entries: []dexSlot{
+					{1, "bulbasaur", dexMissing},
+					{4, "charmander", dexMissing},
+					{7, "squirtle", dexMissing},
+					{25, "pikachu", dexMissing},
+				},
+			},
+		},
+		{
+			// Caught species count as seen, and species from other
+			// regions count for nothing.
+			pokedex: map[string]*DexEntry{
+				"pikachu":  {Species: "pikachu", Caught: true},
+				"squirtle": {Species: "squirtle"},
+				"totodile": {Species: "totodile", Caught: true},
+			},
+			dex: kanto,
+			expected: dexCompletionResult{
+				Dex: "kanto", Total: 4, Caught: 1, Seen: 2, Missing: 2, CaughtPercent: 25, SeenPercent: 50,
+				Entries: []dexSlot{
+					{1, "bulbasaur", dexMissing},
+					{4, "charmander", dexMissing},
+					{7, "squirtle", dexSeen},
+					{25, "pikachu", dexCaught},
+				},
+			},
+		},
+		{
+			// Percentages are rounded to one decimal.
+			pokedex: map[string]*DexEntry{
+				"bulbasaur": {Species: "bulbasaur", Caught: true},
+			},
+			dex: `{"name": "tiny", "pokemon_entries": [
+				{"entry_number": 1, "pokemon_species": {"name": "bulbasaur"}},
+				{"entry_number": 2, "pokemon_species": {"name": "ivysaur"}},
+				{"entry_number": 3, "pokemon_species": {"name": "venusaur"}}
+			]}`,
+			expected: dexCompletionResult{
+				Dex: "tiny", Total: 3, Caught: 1, Seen: 1, Missing: 2, CaughtPercent: 33.3, SeenPercent: 33.3,
+				Entries: []dexSlot{
+					{1, "bulbasaur", dexCaught},
+					{2, "ivysaur", dexMissing},
+					{3, "venusaur", dexMissing},
+				},
+			},
+		},
+		{
+			pokedex: map[string]*DexEntry{
+				"bulbasaur": {Species: "bulbasaur", Caught: true},
+				"ivysaur":   {Species: "ivysaur"},
+			},
+			dex: `{"name": "tiny", "pokemon_entries": [
+				{"entry_number": 1, "pokemon_species": {"name": "bulbasaur"}},
+				{"entry_number": 2, "pokemon_species": {"name": "ivysaur"}},
+				{"entry_number": 3, "pokemon_species": {"name": "venusaur"}}
+			]}`,
+			expected: dexCompletionResult{
+				Dex: "tiny", Total: 3, Caught: 1, Seen: 2, Missing: 1, CaughtPercent: 33.3, SeenPercent: 66.7,
+				Entries: []dexSlot{
+					{1, "bulbasaur", dexCaught},
+					{2, "ivysaur", dexSeen},
+					{3, "venusaur", dexMissing},
+				},
+			},
+		},
+		{
+			// An empty Pokedex is not divided by.
+			pokedex:  map[string]*DexEntry{},
+			dex:      `{"name": "empty", "pokemon_entries": []}`,
+			expected: dexCompletionResult{Dex: "empty", Entries: []dexSlot{}},
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			config := newTestConfig(t, 1)
+			config.Dex = "https://pokeapi.co/api/v2/pokedex/"
+			config.Pokedex = c.pokedex
+			addToCache(t, config, config.Dex+"test", c.dex)
+
+			actual, err := config.dexCompletion("test")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("Expected %+v, got %+v", c.expected, actual)
+			}
+		})
+	}
+}
+
+// testAPI serves API responses by path, with {{url}} in a body standing for
+// the server's URL, and answers 404 for anything else.
+func testAPI(t *testing.T, responses map[string]string) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, strings.ReplaceAll(body, "{{url}}", server.URL))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCommandWhere(t *testing.T) {
+	server := testAPI(t, map[string]string{
+		"/location-area/viridian-forest-area": `{"name": "viridian-forest-area", "pokemon_encounters": [
+			{"pokemon": {"name": "pikachu"}, "version_details": [{"version": {"name": "red"}, "encounter_details": [{"chance": 5, "method": {"name": "walk"}}]}]},
+			{"pokemon": {"name": "raichu-alola"}, "version_details": [{"version": {"name": "sun"}, "encounter_details": [{"chance": 5, "method": {"name": "walk"}}]}]},
+			{"pokemon": {"name": "pikachu"}, "version_details": [{"version": {"name": "blue"}, "encounter_details": [{"chance": 5, "method": {"name": "walk"}}]}]}
+		]}`,
+		"/pokemon/pikachu":           `{"name": "pikachu", "species": {"name": "pikachu", "url": "{{url}}/pokemon-species/25/"}}`,
+		"/pokemon/raichu-alola":      `{"name": "raichu-alola", "species": {"name": "raichu", "url": "{{url}}/pokemon-species/26/"}}`,
+		"/pokemon-species/26/":       `{"name": "raichu"}`,
+		"/pokemon-species/bulbasaur": `{"name": "bulbasaur"}`,
+	})
+
+	exploredAt := time.Date(2026, time.June, 1, 8, 30, 0, 0, time.UTC)
+
+	config := newTestConfig(t, 1)
+	config.Explore = server.URL + "/location-area/"
+	config.Pokemon = server.URL + "/pokemon/"
+	config.Species = server.URL + "/pokemon-species/"
+	config.GameVersion = anyVersion
+	config.Clock = func() time.Time { return exploredAt }
+
+	// Raichu was seen and caught before exploring, once outside of any area.
+	config.Pokedex["raichu"] = &DexEntry{Species: "raichu", Number: 26, Caught: true, SeenAt: exploredAt.AddDate(0, 0, -1), SeenIn: "kanto-route-2", SeenBy: methodWalk}
+	for id, area := range []string{"kanto-route-2", "kanto-route-2", "", "cerulean-cave-1f"} {
+		caught := testCaught(t, id+1)
+		caught.Species = "raichu"
+		caught.CaughtIn = area
+		config.Owned[caught.ID] = caught
+	}
+
+	if _, err := commandExplore(config, cmdArgs{positional: []string{"viridian-forest-area"}}); err != nil {
+		t.Fatalf("Unexpected error exploring: %v", err)
+	}
+
+	cases := []struct {
+		input    string
+		expected whereResult
+		err      string
+	}{
+		{
+			input: "pikachu",
+			expected: whereResult{
+				Species: "pikachu", Number: 25, Status: dexSeen,
+				SeenAt: exploredAt, SeenIn: "viridian-forest-area", SeenBy: seenExploring, CaughtIn: []string{},
+			},
+		},
+		{
+			// Forms are looked up by their species, which keeps its
+			// first sighting.
+			input: "raichu-alola",
+			expected: whereResult{
+				Species: "raichu", Number: 26, Status: dexCaught,
+				SeenAt: exploredAt.AddDate(0, 0, -1), SeenIn: "kanto-route-2", SeenBy: methodWalk,
+				CaughtIn: []string{"kanto-route-2", "cerulean-cave-1f"},
+			},
+		},
+		{
+			input: "bulbasaur",
+			err:   "you have not seen bulbasaur yet.",
+		},
+		{
+			input: "missingno",
+			err:   "invalid species name.",
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			actual, err := commandWhere(config, cmdArgs{positional: []string{c.input}})
+			if c.err != "" {
+				if err == nil || err.Error() != c.err {
+					t.Errorf("Expected error %q, got %v", c.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("Expected %+v, got %+v", c.expected, actual)
+			}
+		})
+	}
+}
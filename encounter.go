@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// fleeChance is the percent chance a wild Pokemon runs away after breaking
+// out of a ball.
+const fleeChance = 20
+
+type encounterResult struct {
+	Pokemon    string   `json:"pokemon"`
+	Level      int      `json:"level"`
+	Area       string   `json:"area"`
+	Method     string   `json:"method"`
+	Conditions []string `json:"conditions"`
+}
+
+func (r encounterResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "A wild %s (Lv %d) appeared in %s!\n", r.Pokemon, r.Level, r.Area)
+	if len(r.Conditions) > 0 {
+		fmt.Fprintf(w, "It only shows up here with %s.\n", strings.Join(r.Conditions, ", "))
+	}
+	fmt.Fprintln(w, "Throw a ball at it with catch, or fight it with battle.")
+	fmt.Fprintln(w)
+}
+
+// worldConditions returns the condition value the world is in for each
+// encounter condition the Pokedex keeps track of. Conditions not listed here,
+// such as story progress, never hold an encounter back.
+func worldConditions(now time.Time) map[string]string {
+	timeOfDay := "time-night"
+	switch hour := now.Hour(); {
+	case hour >= 4 && hour < 10:
+		timeOfDay = "time-morning"
+	case hour >= 10 && hour < 20:
+		timeOfDay = "time-day"
+	}
+
+	// Seasons change every month, starting with spring in January.
+	seasons := []string{"season-spring", "season-summer", "season-autumn", "season-winter"}
+
+	return map[string]string{
+		"time":   timeOfDay,
+		"season": seasons[(int(now.Month())-1)%len(seasons)],
+		"swarm":  "swarm-no",
+		"radar":  "radar-off",
+		"radio":  "radio-off",
+		"slot2":  "slot2-none",
+	}
+}
+
+// conditionsMet reports whether the world is in one of the encounter's
+// values for every condition it has.
+func (e wildEncounter) conditionsMet(world map[string]string) bool {
+	met := map[string]bool{}
+	for _, value := range e.Conditions {
+		condition, _, _ := strings.Cut(value, "-")
+		current, ok := world[condition]
+		if !ok {
+			continue
+		}
+		met[condition] = met[condition] || current == value
+	}
+
+	for _, ok := range met {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// possibleEncounters keeps the encounters whose conditions are met at the
+// time now.
+func possibleEncounters(encounters []wildEncounter, now time.Time) []wildEncounter {
+	world := worldConditions(now)
+	possible := []wildEncounter{}
+	for _, encounter := range encounters {
+		if encounter.conditionsMet(world) {
+			possible = append(possible, encounter)
+		}
+	}
+	return possible
+}
+
+// pickEncounter picks an encounter weighted by the encounters' chances.
+func pickEncounter(rng *rand.Rand, encounters []wildEncounter) wildEncounter {
+	total := 0
+	for _, encounter := range encounters {
+		total += encounter.Chance
+	}
+
+	if total <= 0 {
+		return encounters[rng.Intn(len(encounters))]
+	}
+
+	roll := rng.Intn(total)
+	for _, encounter := range encounters {
+		if roll < encounter.Chance {
+			return encounter
+		}
+		roll -= encounter.Chance
+	}
+	return encounters[len(encounters)-1]
+}
+
+func commandEncounter(config *Config, args cmdArgs) (any, error) {
+	if config.Battle != nil {
+		return nil, errInBattle
+	}
+
+	area, err := config.currentArea()
+	if err != nil {
+		return nil, err
+	}
+
+	method := args.flag("method")
+	encounters := []wildEncounter{}
+	for _, encounter := range possibleEncounters(config.areaEncounters(area), config.Clock()) {
+		if encounter.Method == method {
+			encounters = append(encounters, encounter)
+		}
+	}
+
+	if len(encounters) == 0 {
+		return nil, fmt.Errorf("no pokemon can be met in %s with %s right now.", area.Name, method)
+	}
+
+	encounter := pickEncounter(config.Rand, encounters)
+	pokemon, err := config.getPokemon(encounter.Pokemon)
+	if err != nil {
+		return nil, err
+	}
+	species, err := config.getSpeciesOf(pokemon)
+	if err != nil {
+		return nil, err
+	}
+
+	wild, err := config.rollWild(pokemon, species, encounter.Method, encounter.rollLevel(config.Rand))
+	if err != nil {
+		return nil, err
+	}
+	config.Wild = wild
+
+	return encounterResult{
+		Pokemon:    pokemon.Name,
+		Level:      config.Wild.Level,
+		Area:       area.Name,
+		Method:     method,
+		Conditions: encounter.Conditions,
+	}, nil
+}
+
+// catchEncountered throws a ball at the wild Pokemon, which may flee when it
+// breaks free. In a battle it fights back instead.
+func (config *Config) catchEncountered(name string, ball string) (any, error) {
+	wild := config.Wild
+	if name != "" && name != wild.Pokemon.Name {
+		return nil, fmt.Errorf("a wild %s is in the way, catch it or let it flee first.", wild.Pokemon.Name)
+	}
+
+	caught, err := config.throwBall(wild, ball)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Battle != nil {
+		return config.catchInBattle(caught)
+	}
+
+	if !caught.Caught && config.Rand.Intn(100) < fleeChance {
+		caught.Fled = true
+	}
+	if caught.Caught || caught.Fled {
+		config.Wild = nil
+	}
+
+	return caught, nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPossibleEncounters(t *testing.T) {
+	encounters := []wildEncounter{
+		{Pokemon: "pidgey", Chance: 50},
+		{Pokemon: "hoothoot", Chance: 50, Conditions: []string{"time-night"}},
+		{Pokemon: "ledyba", Chance: 50, Conditions: []string{"time-morning"}},
+		{Pokemon: "spinarak", Chance: 50, Conditions: []string{"time-morning", "time-night"}},
+		{Pokemon: "deerling", Chance: 50, Conditions: []string{"season-winter", "time-day"}},
+		// Radar and swarm encounters need something the player can't do.
+		{Pokemon: "yanma", Chance: 50, Conditions: []string{"swarm-yes"}},
+		{Pokemon: "caterpie", Chance: 50, Conditions: []string{"radar-off"}},
+		// Conditions the Pokedex doesn't keep track of never hold one back.
+		{Pokemon: "snorlax", Chance: 50, Conditions: []string{"story-progress-awakened-snorlax"}},
+	}
+
+	cases := []struct {
+		now      time.Time
+		expected []string
+	}{
+		{
+			now:      time.Date(2026, time.January, 1, 2, 0, 0, 0, time.UTC),
+			expected: []string{"pidgey", "hoothoot", "spinarak", "caterpie", "snorlax"},
+		},
+		{
+			now:      time.Date(2026, time.January, 1, 4, 0, 0, 0, time.UTC),
+			expected: []string{"pidgey", "ledyba", "spinarak", "caterpie", "snorlax"},
+		},
+		{
+			now:      time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC),
+			expected: []string{"pidgey", "caterpie", "snorlax"},
+		},
+		{
+			// Seasons change every month, so April is winter.
+			now:      time.Date(2026, time.April, 1, 12, 0, 0, 0, time.UTC),
+			expected: []string{"pidgey", "deerling", "caterpie", "snorlax"},
+		},
+		{
+			now:      time.Date(2026, time.December, 31, 19, 59, 0, 0, time.UTC),
+			expected: []string{"pidgey", "deerling", "caterpie", "snorlax"},
+		},
+		{
+			now:      time.Date(2026, time.December, 31, 20, 0, 0, 0, time.UTC),
+			expected: []string{"pidgey", "hoothoot", "spinarak", "caterpie", "snorlax"},
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			config := newTestConfig(t, 1)
+			config.Clock = func() time.Time { return c.now }
+
+			actual := []string{}
+			for _, encounter := range possibleEncounters(encounters, config.Clock()) {
+				actual = append(actual, encounter.Pokemon)
+			}
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("Expected %v at %v, got %v", c.expected, c.now, actual)
+			}
+		})
+	}
+}
+
+func TestPickEncounter(t *testing.T) {
+	cases := []struct {
+		encounters []wildEncounter
+		expected   map[string]bool
+	}{
+		{
+			encounters: []wildEncounter{{Pokemon: "pidgey", Chance: 100}},
+			expected:   map[string]bool{"pidgey": true},
+		},
+		{
+			encounters: []wildEncounter{{Pokemon: "pidgey", Chance: 0}, {Pokemon: "rattata", Chance: 30}},
+			expected:   map[string]bool{"rattata": true},
+		},
+		{
+			// Without any chances, every encounter is as likely.
+			encounters: []wildEncounter{{Pokemon: "pidgey"}, {Pokemon: "rattata"}},
+			expected:   map[string]bool{"pidgey": true, "rattata": true},
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			config := newTestConfig(t, 42)
+			actual := map[string]bool{}
+			for range 100 {
+				actual[pickEncounter(config.Rand, c.encounters).Pokemon] = true
+			}
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("Expected to pick %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
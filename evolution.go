@@ -0,0 +1,457 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"pokedex/internal/stats"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Evolution triggers as the API names them.
+const (
+	triggerLevelUp = "level-up"
+	triggerUseItem = "use-item"
+	triggerTrade   = "trade"
+)
+
+// genders maps the API's gender IDs in evolution details to genders.
+var genders = map[int]string{1: "female", 2: "male"}
+
+type evolutionNode struct {
+	Species    string          `json:"species"`
+	Caught     bool            `json:"caught"`
+	Conditions []string        `json:"conditions"`
+	EvolvesTo  []evolutionNode `json:"evolves_to"`
+}
+
+type evolutionResult struct {
+	Chain evolutionNode `json:"chain"`
+}
+
+func (r evolutionResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, r.Chain.label())
+	renderBranches(w, r.Chain.EvolvesTo, "")
+	fmt.Fprintln(w)
+}
+
+// renderBranches draws the evolutions of a node as an ASCII tree.
+func renderBranches(w io.Writer, nodes []evolutionNode, indent string) {
+	for i, node := range nodes {
+		branch, next := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, next = "└── ", "    "
+		}
+
+		line := node.label()
+		if len(node.Conditions) > 0 {
+			line += " (" + strings.Join(node.Conditions, " or ") + ")"
+		}
+		fmt.Fprintln(w, indent+branch+line)
+
+		renderBranches(w, node.EvolvesTo, indent+next)
+	}
+}
+
+func (n evolutionNode) label() string {
+	if n.Caught {
+		return n.Species + " *"
+	}
+	return n.Species
+}
+
+func (r evolutionResult) tableRows() ([]string, [][]string) {
+	rows := [][]string{}
+
+	var walk func(node evolutionNode, from string)
+	walk = func(node evolutionNode, from string) {
+		caught := ""
+		if node.Caught {
+			caught = "yes"
+		}
+		rows = append(rows, []string{node.Species, from, strings.Join(node.Conditions, " or "), caught})
+		for _, next := range node.EvolvesTo {
+			walk(next, node.Species)
+		}
+	}
+	walk(r.Chain, "")
+
+	return []string{"SPECIES", "EVOLVES FROM", "CONDITION", "CAUGHT"}, rows
+}
+
+func commandEvolution(config *Config, args cmdArgs) (any, error) {
+	species, err := config.getSpecies(args.arg(0))
+	if err != nil {
+		return nil, err
+	}
+
+	chain, err := config.getEvolutionChain(species)
+	if err != nil {
+		return nil, err
+	}
+
+	return evolutionResult{Chain: newEvolutionNode(config, chain.Chain)}, nil
+}
+
+func (config *Config) getEvolutionChain(species PokemonSpecies) (EvolutionChain, error) {
+	chain := EvolutionChain{}
+	err := config.fetch(species.EvolutionChain.URL, &chain)
+	return chain, err
+}
+
+func newEvolutionNode(config *Config, link EvolutionChainLink) evolutionNode {
+	node := evolutionNode{
+		Species:    link.Species.Name,
+		Caught:     config.hasCaughtSpecies(link.Species.Name),
+		Conditions: []string{},
+		EvolvesTo:  []evolutionNode{},
+	}
+
+	for _, detail := range link.EvolutionDetails {
+		node.Conditions = append(node.Conditions, describeEvolution(detail))
+	}
+
+	for _, next := range link.EvolvesTo {
+		node.EvolvesTo = append(node.EvolvesTo, newEvolutionNode(config, next))
+	}
+
+	return node
+}
+
+// findEvolutionLink finds the link of a species in an evolution chain.
+func findEvolutionLink(link EvolutionChainLink, species string) (EvolutionChainLink, bool) {
+	if link.Species.Name == species {
+		return link, true
+	}
+	for _, next := range link.EvolvesTo {
+		if found, ok := findEvolutionLink(next, species); ok {
+			return found, true
+		}
+	}
+	return EvolutionChainLink{}, false
+}
+
+type evolveResult struct {
+	ID        int    `json:"id"`
+	Pokemon   string `json:"pokemon"`
+	EvolvedTo string `json:"evolved_to,omitempty"`
+	Canceled  bool   `json:"canceled"`
+}
+
+func (r evolveResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	if r.Canceled {
+		fmt.Fprintf(w, "Huh? %s stopped evolving!\n", r.Pokemon)
+	} else {
+		fmt.Fprintf(w, "Congratulations! Your %s evolved into %s!\n", r.Pokemon, r.EvolvedTo)
+	}
+	fmt.Fprintln(w)
+}
+
+// evolutionFor returns the species a caught Pokemon evolves into through a
+// trigger, where item is the item used on it, or "" when the conditions of
+// none of its evolutions are met.
+func (config *Config) evolutionFor(caught *CaughtPokemon, trigger string, item string) (string, error) {
+	species, err := config.getSpeciesOf(caught.Pokemon)
+	if err != nil {
+		return "", err
+	}
+
+	chain, err := config.getEvolutionChain(species)
+	if err != nil {
+		return "", err
+	}
+
+	link, _ := findEvolutionLink(chain.Chain, species.Name)
+	for _, next := range link.EvolvesTo {
+		for _, detail := range next.EvolutionDetails {
+			if detail.Trigger.Name != trigger {
+				continue
+			}
+
+			met, err := config.evolutionConditionsMet(caught, detail, item)
+			if err != nil {
+				return "", err
+			}
+			if met {
+				return next.Species.Name, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// evolutionConditionsMet checks the requirements of one evolution method.
+// Requirements the Pokedex does not keep track of, such as held items,
+// beauty or the weather, are never met.
+func (config *Config) evolutionConditionsMet(caught *CaughtPokemon, detail EvolutionDetail, item string) (bool, error) {
+	if detail.HeldItem != nil || detail.MinAffection != nil || detail.MinBeauty != nil || detail.Location != nil ||
+		detail.TradeSpecies != nil || detail.NeedsOverworldRain || detail.TurnUpsideDown {
+		return false, nil
+	}
+
+	switch {
+	case detail.Item != nil && detail.Item.Name != item:
+		return false, nil
+	case detail.MinLevel != nil && caught.Level < *detail.MinLevel:
+		return false, nil
+	case detail.MinHappiness != nil && caught.Friendship < *detail.MinHappiness:
+		return false, nil
+	case detail.TimeOfDay != "" && detail.TimeOfDay != evolutionTime(config.Clock()):
+		return false, nil
+	case detail.Gender != nil && caught.Gender != genders[*detail.Gender]:
+		return false, nil
+	case detail.KnownMove != nil && !slices.Contains(caught.Moves, detail.KnownMove.Name):
+		return false, nil
+	case detail.PartySpecies != nil && !config.partyHas(func(c *CaughtPokemon) bool { return c.Species == detail.PartySpecies.Name }):
+		return false, nil
+	case detail.PartyType != nil && !config.partyHas(func(c *CaughtPokemon) bool { return slices.Contains(pokemonTypes(c.Pokemon), detail.PartyType.Name) }):
+		return false, nil
+	}
+
+	if detail.KnownMoveType != nil {
+		knows := false
+		for _, name := range caught.Moves {
+			move, err := config.getMove(name)
+			if err != nil {
+				return false, err
+			}
+			knows = knows || move.Type.Name == detail.KnownMoveType.Name
+		}
+		if !knows {
+			return false, nil
+		}
+	}
+
+	if detail.RelativePhysicalStats != nil {
+		values, err := config.caughtStats(caught)
+		if err != nil {
+			return false, err
+		}
+		if cmp.Compare(values[stats.Attack], values[stats.Defense]) != *detail.RelativePhysicalStats {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// evolutionTime returns the time of day as evolution details name it, where
+// the morning counts as day.
+func evolutionTime(now time.Time) string {
+	if worldConditions(now)["time"] == "time-night" {
+		return "night"
+	}
+	return "day"
+}
+
+// partyHas reports whether a Pokemon in the party matches.
+func (config *Config) partyHas(match func(*CaughtPokemon) bool) bool {
+	return slices.ContainsFunc(config.Party, func(id int) bool { return match(config.Owned[id]) })
+}
+
+// evolvedForm returns the Pokemon a caught one becomes when it evolves into
+// a species. A regional form such as vulpix-alola keeps its form when the
+// species has it, otherwise it becomes the species' default variety.
+func (config *Config) evolvedForm(caught *CaughtPokemon, into string) (Pokemon, error) {
+	species, err := config.getSpecies(into)
+	if err != nil {
+		return Pokemon{}, err
+	}
+
+	name := ""
+	form, isForm := strings.CutPrefix(caught.Pokemon.Name, caught.Species+"-")
+	for _, variety := range species.Varieties {
+		if isForm && variety.Pokemon.Name == species.Name+"-"+form {
+			name = variety.Pokemon.Name
+			break
+		}
+		if variety.IsDefault && name == "" {
+			name = variety.Pokemon.Name
+		}
+	}
+	if name == "" {
+		name = species.Name
+	}
+
+	return config.getPokemon(name)
+}
+
+// evolve turns a caught Pokemon into the species it evolves into. It keeps
+// its nickname, level, IVs, EVs and moves, and the damage it has taken.
+func (config *Config) evolve(caught *CaughtPokemon, into string) error {
+	evolved, err := config.evolvedForm(caught, into)
+	if err != nil {
+		return err
+	}
+
+	oldMaxHP, err := config.maxHP(caught)
+	if err != nil {
+		return err
+	}
+
+	next := *caught
+	next.Pokemon = evolved
+	next.Species = evolved.Species.Name
+	next.Evolving = ""
+
+	maxHP, err := config.maxHP(&next)
+	if err != nil {
+		return err
+	}
+	if !next.Fainted() {
+		next.HP = max(1, next.HP+maxHP-oldMaxHP)
+	}
+
+	*caught = next
+	config.markSeen(caught.Pokemon, seenEvolving).Caught = true
+	return nil
+}
+
+func commandEvolve(config *Config, args cmdArgs) (any, error) {
+	if config.Battle != nil {
+		return nil, errInBattle
+	}
+
+	caught, err := config.findOwned(args.arg(0))
+	if err != nil {
+		return nil, err
+	}
+	if caught.Evolving == "" {
+		return nil, fmt.Errorf("%s is not evolving.", caught.Name())
+	}
+
+	result := evolveResult{ID: caught.ID, Pokemon: caught.Name()}
+	if args.boolFlag("cancel") {
+		caught.Evolving = ""
+		result.Canceled = true
+		return result, nil
+	}
+
+	err = config.evolve(caught, caught.Evolving)
+	if err != nil {
+		return nil, err
+	}
+	result.EvolvedTo = caught.Pokemon.Name
+	return result, nil
+}
+
+func commandTrade(config *Config, args cmdArgs) (any, error) {
+	if config.Battle != nil {
+		return nil, errInBattle
+	}
+
+	caught, err := config.findOwned(args.arg(0))
+	if err != nil {
+		return nil, err
+	}
+
+	into, err := config.evolutionFor(caught, triggerTrade, "")
+	if err != nil {
+		return nil, err
+	}
+	if into == "" {
+		return message{Message: fmt.Sprintf("You traded %s with a friend and got it back. Nothing happened.", caught.Name())}, nil
+	}
+
+	result := evolveResult{ID: caught.ID, Pokemon: caught.Name()}
+	err = config.evolve(caught, into)
+	if err != nil {
+		return nil, err
+	}
+	result.EvolvedTo = caught.Pokemon.Name
+	return result, nil
+}
+
+// completeEvolving offers the Pokemon that are evolving.
+func completeEvolving(config *Config, before []string) []string {
+	names := []string{}
+	for id, caught := range config.Owned {
+		if caught.Evolving != "" {
+			names = append(names, strconv.Itoa(id))
+		}
+	}
+	return names
+}
+
+// hasCaughtSpecies reports whether any caught Pokemon belongs to the species.
+func (config *Config) hasCaughtSpecies(species string) bool {
+	entry, ok := config.Pokedex[species]
+	return ok && entry.Caught
+}
+
+// describeEvolution turns the requirements of one evolution method into a
+// short phrase such as "level 16" or "use thunder-stone, during the day".
+func describeEvolution(detail EvolutionDetail) string {
+	parts := []string{}
+
+	switch detail.Trigger.Name {
+	case "level-up":
+		if detail.MinLevel != nil {
+			parts = append(parts, fmt.Sprintf("level %d", *detail.MinLevel))
+		} else {
+			parts = append(parts, "level up")
+		}
+	case "use-item":
+		if detail.Item != nil {
+			parts = append(parts, "use "+detail.Item.Name)
+		}
+	case "trade":
+		parts = append(parts, "trade")
+		if detail.TradeSpecies != nil {
+			parts = append(parts, "for "+detail.TradeSpecies.Name)
+		}
+	default:
+		parts = append(parts, detail.Trigger.Name)
+	}
+
+	if detail.MinHappiness != nil {
+		parts = append(parts, fmt.Sprintf("friendship %d", *detail.MinHappiness))
+	}
+	if detail.MinAffection != nil {
+		parts = append(parts, fmt.Sprintf("affection %d", *detail.MinAffection))
+	}
+	if detail.MinBeauty != nil {
+		parts = append(parts, fmt.Sprintf("beauty %d", *detail.MinBeauty))
+	}
+	if detail.HeldItem != nil {
+		parts = append(parts, "holding "+detail.HeldItem.Name)
+	}
+	if detail.KnownMove != nil {
+		parts = append(parts, "knowing "+detail.KnownMove.Name)
+	}
+	if detail.KnownMoveType != nil {
+		parts = append(parts, "knowing a "+detail.KnownMoveType.Name+" move")
+	}
+	if detail.Location != nil {
+		parts = append(parts, "at "+detail.Location.Name)
+	}
+	if detail.TimeOfDay != "" {
+		parts = append(parts, "during the "+detail.TimeOfDay)
+	}
+	if detail.Gender != nil {
+		parts = append(parts, genders[*detail.Gender]+" only")
+	}
+	if detail.PartySpecies != nil {
+		parts = append(parts, "with "+detail.PartySpecies.Name+" in the party")
+	}
+	if detail.PartyType != nil {
+		parts = append(parts, "with a "+detail.PartyType.Name+" type in the party")
+	}
+	if detail.RelativePhysicalStats != nil {
+		parts = append(parts, map[int]string{1: "attack > defense", 0: "attack = defense", -1: "attack < defense"}[*detail.RelativePhysicalStats])
+	}
+	if detail.NeedsOverworldRain {
+		parts = append(parts, "while raining")
+	}
+	if detail.TurnUpsideDown {
+		parts = append(parts, "holding the console upside down")
+	}
+
+	return strings.Join(parts, ", ")
+}
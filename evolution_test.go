@@ -0,0 +1,229 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"pokedex/internal/experience"
+	"testing"
+	"time"
+)
+
+// addToCache stores an API response in the cache, so it is found without
+// the API.
+func addToCache(t *testing.T, config *Config, url string, body string) {
+	if err := config.Cache.Add(url, []byte(body)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testDetail builds evolution details from their JSON.
+func testDetail(t *testing.T, body string) EvolutionDetail {
+	detail := EvolutionDetail{}
+	if err := json.Unmarshal([]byte(body), &detail); err != nil {
+		t.Fatal(err)
+	}
+	return detail
+}
+
+func TestEvolutionConditionsMet(t *testing.T) {
+	day := time.Date(2026, time.May, 1, 12, 0, 0, 0, time.UTC)
+	night := time.Date(2026, time.May, 1, 23, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		detail   string
+		level    int
+		item     string
+		now      time.Time
+		expected bool
+	}{
+		{
+			detail:   `{"min_level": 16}`,
+			level:    16,
+			expected: true,
+		},
+		{
+			detail:   `{"min_level": 16}`,
+			level:    15,
+			expected: false,
+		},
+		{
+			detail:   `{"item": {"name": "thunder-stone"}}`,
+			item:     "thunder-stone",
+			expected: true,
+		},
+		{
+			detail:   `{"item": {"name": "thunder-stone"}}`,
+			item:     "fire-stone",
+			expected: false,
+		},
+		{
+			detail:   `{"min_happiness": 160}`,
+			expected: false,
+		},
+		{
+			detail:   `{"min_happiness": 160, "time_of_day": "night"}`,
+			now:      night,
+			expected: false,
+		},
+		{
+			detail:   `{"min_happiness": 70, "time_of_day": "day"}`,
+			now:      day,
+			expected: true,
+		},
+		{
+			detail:   `{"min_happiness": 70, "time_of_day": "day"}`,
+			now:      night,
+			expected: false,
+		},
+		{
+			detail:   `{"gender": 2}`,
+			expected: true,
+		},
+		{
+			detail:   `{"gender": 1}`,
+			expected: false,
+		},
+		{
+			detail:   `{"known_move": {"name": "thunder-shock"}}`,
+			expected: true,
+		},
+		{
+			detail:   `{"known_move": {"name": "surf"}}`,
+			expected: false,
+		},
+		{
+			detail:   `{"party_species": {"name": "pikachu"}}`,
+			expected: true,
+		},
+		{
+			detail:   `{"party_species": {"name": "remoraid"}}`,
+			expected: false,
+		},
+		{
+			// Held items are not kept track of, so they are never met.
+			detail:   `{"held_item": {"name": "metal-coat"}}`,
+			expected: false,
+		},
+		{
+			detail:   `{"needs_overworld_rain": true, "min_level": 50}`,
+			level:    50,
+			expected: false,
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			now := c.now
+			if now.IsZero() {
+				now = day
+			}
+			config := newTestConfig(t, 1)
+			config.Clock = func() time.Time { return now }
+
+			caught := testCaught(t, 1)
+			caught.Level = max(5, c.level)
+			caught.Friendship = 70
+			caught.Gender = "male"
+			caught.Moves = []string{"thunder-shock", "growl"}
+			config.Owned[caught.ID] = caught
+			config.Party = []int{caught.ID}
+
+			actual, err := config.evolutionConditionsMet(caught, testDetail(t, c.detail), c.item)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if actual != c.expected {
+				t.Errorf("Expected %v for %s, got %v", c.expected, c.detail, actual)
+			}
+		})
+	}
+}
+
+func TestCommandEvolve(t *testing.T) {
+	config := newTestConfig(t, 1)
+	config.Pokemon = "https://pokeapi.co/api/v2/pokemon/"
+	config.Species = "https://pokeapi.co/api/v2/pokemon-species/"
+
+	addToCache(t, config, "https://pokeapi.co/api/v2/pokemon-species/25/",
+		`{"name": "pikachu", "growth_rate": {"name": "medium"}, "evolution_chain": {"url": "https://pokeapi.co/api/v2/evolution-chain/10/"}}`)
+	addToCache(t, config, "https://pokeapi.co/api/v2/evolution-chain/10/",
+		`{"chain": {"species": {"name": "pikachu"}, "evolves_to": [
+			{"species": {"name": "raichu"}, "evolution_details": [{"trigger": {"name": "level-up"}, "min_level": 20}]}
+		]}}`)
+	raichu := `{"name": "raichu", "growth_rate": {"name": "medium"}, "evolution_chain": {"url": "https://pokeapi.co/api/v2/evolution-chain/10/"},
+		"varieties": [{"is_default": true, "pokemon": {"name": "raichu"}}]}`
+	addToCache(t, config, config.Species+"raichu", raichu)
+	addToCache(t, config, "https://pokeapi.co/api/v2/pokemon-species/26/", raichu)
+	addToCache(t, config, config.Pokemon+"raichu",
+		`{"name": "raichu", "species": {"name": "raichu", "url": "https://pokeapi.co/api/v2/pokemon-species/26/"},
+		"stats": [{"base_stat": 60, "stat": {"name": "hp"}}]}`)
+
+	caught := testCaught(t, 1)
+	caught.Nature = "hardy"
+	caught.Experience = 125
+	caught.HP = 20
+	config.Owned[caught.ID] = caught
+	config.Party = []int{caught.ID}
+
+	// Each step levels the Pokemon up to level, if it is higher, then
+	// evolves it or cancels its evolution when asked to.
+	steps := []struct {
+		level int
+		// evolve is "" to only level up, "evolve" or "cancel".
+		evolve    string
+		evolving  string
+		species   string
+		canceled  bool
+		evolvedTo string
+		err       string
+	}{
+		{level: 19, evolving: "", species: "pikachu"},
+		{evolve: "evolve", species: "pikachu", err: "pikachu is not evolving."},
+		{level: 20, evolving: "raichu", species: "pikachu"},
+		{evolve: "cancel", evolving: "", species: "pikachu", canceled: true},
+		// It tries again on the next level-up.
+		{level: 21, evolving: "raichu", species: "pikachu"},
+		{evolve: "evolve", evolving: "", species: "raichu", evolvedTo: "raichu"},
+		{level: 22, evolving: "", species: "raichu"},
+	}
+
+	for i, step := range steps {
+		if step.level > caught.Level {
+			points, err := experience.ForLevel("medium", step.level)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := config.gainExperience(caught, points-caught.Experience); err != nil {
+				t.Fatalf("Step %v: unexpected error: %v", i, err)
+			}
+		}
+
+		if step.evolve != "" {
+			args := cmdArgs{positional: []string{"1"}, flags: map[string]string{"cancel": fmt.Sprint(step.evolve == "cancel")}}
+			result, err := commandEvolve(config, args)
+			if step.err != "" {
+				if err == nil || err.Error() != step.err {
+					t.Errorf("Step %v: expected error %q, got %v", i, step.err, err)
+				}
+			} else if err != nil {
+				t.Fatalf("Step %v: unexpected error: %v", i, err)
+			} else {
+				evolved := result.(evolveResult)
+				if evolved.Canceled != step.canceled || evolved.EvolvedTo != step.evolvedTo {
+					t.Errorf("Step %v: expected canceled %v and evolved to %q, got %+v", i, step.canceled, step.evolvedTo, evolved)
+				}
+			}
+		}
+
+		if caught.Evolving != step.evolving {
+			t.Errorf("Step %v: expected evolving into %q, got %q", i, step.evolving, caught.Evolving)
+		}
+		if caught.Species != step.species {
+			t.Errorf("Step %v: expected species %q, got %q", i, step.species, caught.Species)
+		}
+	}
+
+	if entry := config.Pokedex["raichu"]; entry == nil || !entry.Caught || entry.SeenBy != seenEvolving {
+		t.Errorf("Expected raichu to be caught by evolving, got %+v", entry)
+	}
+}
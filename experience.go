@@ -0,0 +1,253 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"pokedex/internal/experience"
+	"slices"
+	"strings"
+)
+
+// experienceGain is what happened to one of the player's Pokemon after it
+// earned experience.
+type experienceGain struct {
+	ID         int      `json:"id"`
+	Pokemon    string   `json:"pokemon"`
+	Experience int      `json:"experience"`
+	Level      int      `json:"level"`
+	LevelsUp   int      `json:"levels_up"`
+	Learned    []string `json:"learned"`
+	// WantsToLearn are moves that could not be learned because the
+	// Pokemon already knows four.
+	WantsToLearn []string `json:"wants_to_learn"`
+	// Evolving is the species the Pokemon wants to evolve into.
+	Evolving string `json:"evolving,omitempty"`
+}
+
+// maxFriendship is the highest friendship a Pokemon can have.
+const maxFriendship = 255
+
+// friendshipGain returns how much a level-up raises a Pokemon's friendship,
+// which grows slower the closer it gets to its trainer.
+func friendshipGain(friendship int) int {
+	switch {
+	case friendship < 100:
+		return 5
+	case friendship < 200:
+		return 3
+	}
+	return 2
+}
+
+func (g experienceGain) render(w io.Writer) {
+	fmt.Fprintf(w, "%s gained %d experience points!\n", g.Pokemon, g.Experience)
+	if g.LevelsUp > 0 {
+		fmt.Fprintf(w, "%s grew to level %d!\n", g.Pokemon, g.Level)
+	}
+	for _, move := range g.Learned {
+		fmt.Fprintf(w, "%s learned %s!\n", g.Pokemon, move)
+	}
+	for _, move := range g.WantsToLearn {
+		fmt.Fprintf(w, "%s wants to learn %s, but it already knows %d moves.\n", g.Pokemon, move, maxMoves)
+		fmt.Fprintf(w, "Use \"learn %d %s --forget MOVE\" to replace a move, or \"learn %d %s --skip\".\n", g.ID, move, g.ID, move)
+	}
+	if g.Evolving != "" {
+		fmt.Fprintf(w, "What? %s is evolving into %s!\n", g.Pokemon, g.Evolving)
+		fmt.Fprintf(w, "Use \"evolve %d\" to let it evolve, or \"evolve %d --cancel\" to stop it.\n", g.ID, g.ID)
+	}
+}
+
+type learnResult struct {
+	Pokemon string   `json:"pokemon"`
+	Moves   []string `json:"moves"`
+	Pending []string `json:"pending"`
+}
+
+func (r learnResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s knows: %s\n", r.Pokemon, strings.Join(r.Moves, ", "))
+	if len(r.Pending) == 0 {
+		fmt.Fprintf(w, "%s has no moves waiting to be learned.\n", r.Pokemon)
+	} else {
+		fmt.Fprintf(w, "%s wants to learn: %s\n", r.Pokemon, strings.Join(r.Pending, ", "))
+	}
+	fmt.Fprintln(w)
+}
+
+// growthRate returns the name of the growth rate of a Pokemon's species.
+func (config *Config) growthRate(pokemon Pokemon) (string, error) {
+	species, err := config.getSpeciesOf(pokemon)
+	if err != nil {
+		return "", err
+	}
+	return species.GrowthRate.Name, nil
+}
+
+// experienceEarner returns the Pokemon that earns experience for defeating
+// or catching the wild Pokemon: the one out in a battle, or else the lead of
+// the party.
+func (config *Config) experienceEarner() (*CaughtPokemon, bool) {
+	if config.Battle != nil {
+		return config.Battle.Active, !config.Battle.Active.Fainted()
+	}
+	return config.partyLead()
+}
+
+// awardExperience gives the experience for defeating or catching a wild
+// Pokemon to the Pokemon that earned it, or nil when none did.
+func (config *Config) awardExperience(wild *CaughtPokemon) (*experienceGain, error) {
+	earner, ok := config.experienceEarner()
+	if !ok || earner == wild {
+		return nil, nil
+	}
+
+	gain, err := config.gainExperience(earner, experience.Gain(wild.Pokemon.BaseExperience, wild.Level))
+	if err != nil {
+		return nil, err
+	}
+	return &gain, nil
+}
+
+// gainExperience adds experience to a Pokemon and levels it up when it has
+// enough. On each new level it learns the moves its species learns there,
+// as long as it knows fewer than four; the others wait for learn.
+func (config *Config) gainExperience(caught *CaughtPokemon, points int) (experienceGain, error) {
+	rate, err := config.growthRate(caught.Pokemon)
+	if err != nil {
+		return experienceGain{}, err
+	}
+
+	oldLevel := caught.Level
+	oldMaxHP, err := config.maxHP(caught)
+	if err != nil {
+		return experienceGain{}, err
+	}
+
+	caught.Experience += points
+	level, err := experience.Level(rate, caught.Experience)
+	if err != nil {
+		return experienceGain{}, err
+	}
+	caught.Level = max(caught.Level, level)
+
+	gain := experienceGain{
+		ID:           caught.ID,
+		Pokemon:      caught.Name(),
+		Experience:   points,
+		Level:        caught.Level,
+		LevelsUp:     caught.Level - oldLevel,
+		Learned:      []string{},
+		WantsToLearn: []string{},
+	}
+	if gain.LevelsUp == 0 {
+		return gain, nil
+	}
+
+	// A level-up raises the HP by as much as the HP stat grew.
+	maxHP, err := config.maxHP(caught)
+	if err != nil {
+		return experienceGain{}, err
+	}
+	if !caught.Fainted() {
+		caught.HP += maxHP - oldMaxHP
+	}
+
+	for range gain.LevelsUp {
+		caught.Friendship = min(maxFriendship, caught.Friendship+friendshipGain(caught.Friendship))
+	}
+
+	for _, move := range levelUpMoves(caught.Pokemon, oldLevel+1, caught.Level) {
+		if slices.Contains(caught.Moves, move) || slices.Contains(caught.PendingMoves, move) {
+			continue
+		}
+		if len(caught.Moves) < maxMoves {
+			caught.Moves = append(caught.Moves, move)
+			gain.Learned = append(gain.Learned, move)
+			continue
+		}
+		caught.PendingMoves = append(caught.PendingMoves, move)
+		gain.WantsToLearn = append(gain.WantsToLearn, move)
+	}
+
+	into, err := config.evolutionFor(caught, triggerLevelUp, "")
+	if err != nil {
+		return experienceGain{}, err
+	}
+	if into != "" {
+		caught.Evolving = into
+		gain.Evolving = into
+	}
+
+	return gain, nil
+}
+
+// levelUpMoves lists the moves a Pokemon learns from level from up to and
+// including level to, in the newest game it appears in.
+func levelUpMoves(pokemon Pokemon, from int, to int) []string {
+	moves := []string{}
+	for _, move := range newMovesResult(pokemon, latestVersionGroup(pokemon), "level-up").Moves {
+		if move.Level >= from && move.Level <= to {
+			moves = append(moves, move.Name)
+		}
+	}
+	return moves
+}
+
+func commandLearn(config *Config, args cmdArgs) (any, error) {
+	if config.Battle != nil {
+		return nil, errInBattle
+	}
+
+	caught, err := config.findOwned(args.arg(0))
+	if err != nil {
+		return nil, err
+	}
+
+	move := args.arg(1)
+	if move == "" {
+		return learnResult{Pokemon: caught.Name(), Moves: caught.Moves, Pending: slices.Clone(caught.PendingMoves)}, nil
+	}
+
+	i := slices.Index(caught.PendingMoves, move)
+	if i < 0 {
+		return nil, fmt.Errorf("%s is not trying to learn %s.", caught.Name(), move)
+	}
+
+	forget, skip := args.flag("forget"), args.boolFlag("skip")
+	switch {
+	case skip && forget != "":
+		return nil, usageError{commands["learn"], "pass either --forget or --skip"}
+	case skip:
+		caught.PendingMoves = slices.Delete(caught.PendingMoves, i, i+1)
+		return message{Message: fmt.Sprintf("%s did not learn %s.", caught.Name(), move)}, nil
+	case forget == "" && len(caught.Moves) >= maxMoves:
+		return nil, usageError{commands["learn"], fmt.Sprintf("%s already knows %d moves, pass --forget MOVE or --skip", caught.Name(), maxMoves)}
+	case forget == "":
+		caught.PendingMoves = slices.Delete(caught.PendingMoves, i, i+1)
+		caught.Moves = append(caught.Moves, move)
+		return message{Message: fmt.Sprintf("%s learned %s!", caught.Name(), move)}, nil
+	}
+
+	j := slices.Index(caught.Moves, forget)
+	if j < 0 {
+		return nil, fmt.Errorf("%s doesn't know %s.", caught.Name(), forget)
+	}
+
+	caught.PendingMoves = slices.Delete(caught.PendingMoves, i, i+1)
+	caught.Moves[j] = move
+	return message{Message: fmt.Sprintf("1, 2 and... Poof! %s forgot %s and learned %s!", caught.Name(), forget, move)}, nil
+}
+
+// completeLearn offers the Pokemon that want to learn a move, and then the
+// moves they want to learn.
+func completeLearn(config *Config, before []string) []string {
+	if len(before) == 0 {
+		return completeCaught(config, before)
+	}
+
+	caught, err := config.findOwned(before[0])
+	if err != nil {
+		return nil
+	}
+	return caught.PendingMoves
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	categoryExploring = "Exploring"
+	categoryPokemon   = "Pokemon"
+	categoryBattle    = "Battle"
+	categoryTypes     = "Types"
+	categoryItems     = "Items"
+	categoryScripting = "Scripting"
+	categoryGeneral   = "General"
+)
+
+// categoryOrder is the order categories are listed in by help.
+var categoryOrder = []string{categoryExploring, categoryPokemon, categoryBattle, categoryTypes, categoryItems, categoryScripting, categoryGeneral}
+
+// maxSuggestionDistance is the largest edit distance at which an unknown
+// command is still considered a typo of a known one.
+const maxSuggestionDistance = 2
+
+type commandInfo struct {
+	Name        string `json:"name"`
+	Usage       string `json:"usage"`
+	Description string `json:"description"`
+	Category    string `json:"category"`
+}
+
+type helpResult struct {
+	Commands []commandInfo `json:"commands"`
+	Aliases  []aliasInfo   `json:"aliases"`
+}
+
+func (r helpResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w, "\nWelcome to the Pokedex!\nUsage:")
+
+	category := ""
+	for _, command := range r.Commands {
+		if command.Category != category {
+			category = command.Category
+			fmt.Fprintf(w, "\n%s:\n", category)
+		}
+		fmt.Fprintf(w, "  %s: %s\n", command.Usage, command.Description)
+	}
+
+	if len(r.Aliases) > 0 {
+		fmt.Fprintln(w, "\nAliases:")
+		for _, alias := range r.Aliases {
+			fmt.Fprintf(w, "  %s: %s\n", alias.Name, alias.Expansion)
+		}
+	}
+
+	fmt.Fprintln(w, "\nType \"help COMMAND\" for more about a command.")
+	fmt.Fprintln(w, "")
+}
+
+func (r helpResult) tableRows() ([]string, [][]string) {
+	rows := [][]string{}
+	for _, command := range r.Commands {
+		rows = append(rows, []string{command.Category, command.Usage, command.Description})
+	}
+	for _, alias := range r.Aliases {
+		rows = append(rows, []string{"Aliases", alias.Name, "alias for: " + alias.Expansion})
+	}
+	return []string{"CATEGORY", "COMMAND", "DESCRIPTION"}, rows
+}
+
+type flagInfo struct {
+	Name    string `json:"name"`
+	Value   string `json:"value,omitempty"`
+	Default string `json:"default,omitempty"`
+	Usage   string `json:"usage"`
+}
+
+type commandHelpResult struct {
+	commandInfo
+	Help     string     `json:"help,omitempty"`
+	Flags    []flagInfo `json:"flags"`
+	Examples []string   `json:"examples"`
+}
+
+func (r commandHelpResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s - %s\n", r.Name, r.Description)
+	fmt.Fprintf(w, "\nUsage: %s\n", r.Usage)
+
+	if r.Help != "" {
+		fmt.Fprintf(w, "\n%s\n", r.Help)
+	}
+
+	if len(r.Flags) > 0 {
+		fmt.Fprintln(w, "\nFlags:")
+		for _, f := range r.Flags {
+			name := "--" + f.Name
+			if f.Value != "" {
+				name += " " + f.Value
+			}
+			usage := f.Usage
+			if f.Default != "" {
+				usage += fmt.Sprintf(" (default %s)", f.Default)
+			}
+			fmt.Fprintf(w, "  %s  %s\n", name, usage)
+		}
+	}
+
+	if len(r.Examples) > 0 {
+		fmt.Fprintln(w, "\nExamples:")
+		for _, example := range r.Examples {
+			fmt.Fprintf(w, "  %s\n", example)
+		}
+	}
+
+	fmt.Fprintln(w)
+}
+
+func commandHelp(config *Config, args cmdArgs) (any, error) {
+	name := args.arg(0)
+
+	if name != "" {
+		return commandDetail(config, name)
+	}
+
+	result := helpResult{
+		Commands: []commandInfo{},
+		Aliases:  config.aliasList().Aliases,
+	}
+
+	for _, command := range commands {
+		result.Commands = append(result.Commands, newCommandInfo(command))
+	}
+
+	sort.Slice(result.Commands, func(i, j int) bool {
+		a, b := result.Commands[i], result.Commands[j]
+		if a.Category != b.Category {
+			return categoryRank(a.Category) < categoryRank(b.Category)
+		}
+		return a.Name < b.Name
+	})
+
+	return result, nil
+}
+
+func commandDetail(config *Config, name string) (any, error) {
+	if expansion, ok := config.Aliases[name]; ok {
+		return aliasList{Aliases: []aliasInfo{{Name: name, Expansion: expansion}}}, nil
+	}
+
+	command, ok := commands[name]
+	if !ok {
+		return nil, unknownCommandError(config, name)
+	}
+
+	result := commandHelpResult{
+		commandInfo: newCommandInfo(command),
+		Help:        command.help,
+		Flags:       []flagInfo{},
+		Examples:    command.examples,
+	}
+	if result.Examples == nil {
+		result.Examples = []string{}
+	}
+
+	for _, f := range command.flags {
+		info := flagInfo{Name: f.name, Default: f.def, Usage: f.usage}
+		if f.kind != flagBool {
+			info.Value = f.value
+		}
+		result.Flags = append(result.Flags, info)
+	}
+
+	return result, nil
+}
+
+func newCommandInfo(command cliCommand) commandInfo {
+	return commandInfo{
+		Name:        command.name,
+		Usage:       command.usage(),
+		Description: command.description,
+		Category:    command.category,
+	}
+}
+
+func categoryRank(category string) int {
+	for i, c := range categoryOrder {
+		if c == category {
+			return i
+		}
+	}
+	return len(categoryOrder)
+}
+
+// unknownCommandError suggests the commands and aliases closest to name.
+func unknownCommandError(config *Config, name string) error {
+	suggestions := suggestCommands(config, name)
+	if len(suggestions) == 0 {
+		return fmt.Errorf(`unknown command %q. Type "help" for list of commands`, name)
+	}
+	return fmt.Errorf("unknown command %q. Did you mean: %s?", name, strings.Join(suggestions, ", "))
+}
+
+func suggestCommands(config *Config, name string) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+
+	candidates := []candidate{}
+	for _, known := range completeCommands(config, nil) {
+		// Commands the name is a prefix of are listed first.
+		distance := editDistance(name, known)
+		if len(name) > 1 && strings.HasPrefix(known, name) {
+			distance = 0
+		}
+		if distance <= maxSuggestionDistance {
+			candidates = append(candidates, candidate{known, distance})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].name < candidates[j].name
+	})
+
+	suggestions := []string{}
+	for _, c := range candidates {
+		suggestions = append(suggestions, c.name)
+	}
+	return suggestions
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "catch", b: "catch", expected: 0},
+		{a: "", b: "catch", expected: 5},
+		{a: "catch", b: "", expected: 5},
+		{a: "cach", b: "catch", expected: 1},
+		{a: "ctach", b: "catch", expected: 2},
+		{a: "mapb", b: "map", expected: 1},
+		{a: "kitten", b: "sitting", expected: 3},
+		{a: "pokémon", b: "pokemon", expected: 1},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			actual := editDistance(c.a, c.b)
+			if actual != c.expected {
+				t.Errorf("Expected %v between %q and %q, got %v", c.expected, c.a, c.b, actual)
+			}
+		})
+	}
+}
+
+func TestUnknownCommandError(t *testing.T) {
+	commands = getCommands()
+
+	cases := []struct {
+		aliases  map[string]string
+		input    string
+		expected []string
+	}{
+		{
+			input:    "cach",
+			expected: []string{"catch"},
+		},
+		{
+			input:    "mapp",
+			expected: []string{"map", "mapb"},
+		},
+		{
+			// Commands starting with the input are listed before close ones.
+			input:    "mov",
+			expected: []string{"move", "moves", "box", "map"},
+		},
+		{
+			input:    "xyzzy",
+			expected: []string{},
+		},
+		{
+			aliases:  map[string]string{"grind": "explore mt-coronet-1f; catch geodude"},
+			input:    "grnd",
+			expected: []string{"grind"},
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			config := &Config{Aliases: c.aliases}
+			actual := suggestCommands(config, c.input)
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Fatalf("Expected suggestions %q for %q, got %q", c.expected, c.input, actual)
+			}
+
+			expected := fmt.Sprintf(`unknown command %q. Type "help" for list of commands`, c.input)
+			if len(c.expected) > 0 {
+				expected = fmt.Sprintf("unknown command %q. Did you mean: %s?", c.input, strings.Join(c.expected, ", "))
+			}
+			if err := unknownCommandError(config, c.input); err == nil || err.Error() != expected {
+				t.Errorf("Expected error %q, got %v", expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,409 @@
+package battle
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"pokedex/internal/stats"
+	"slices"
+)
+
+// Non-volatile status conditions.
+const (
+	Paralysis = "paralysis"
+	Sleep     = "sleep"
+	Poison    = "poison"
+	Burn      = "burn"
+	Freeze    = "freeze"
+)
+
+var statuses = []string{Paralysis, Sleep, Poison, Burn, Freeze}
+
+// critChance is the 1 in critChance chance of a critical hit.
+const critChance = 24
+
+// TypeChart tells how effective an attack type is against a defender's types.
+type TypeChart interface {
+	Effectiveness(attack string, defenders ...string) (float64, error)
+}
+
+// Move is what the engine needs to know of a move.
+type Move struct {
+	Name string
+	Type string
+	// DamageClass is "physical", "special" or "status".
+	DamageClass string
+	// Power is 0 for moves that do not deal fixed damage.
+	Power int
+	// Accuracy is 0 for moves that never miss.
+	Accuracy int
+	Priority int
+	// Ailment is the status condition the move may cause, with a percent
+	// AilmentChance, or always for status moves.
+	Ailment       string
+	AilmentChance int
+}
+
+// Struggle is used by Pokemon that have no moves.
+var Struggle = Move{Name: "struggle", Type: "normal", DamageClass: "physical", Power: 50}
+
+// Pokemon is one side of a battle.
+type Pokemon struct {
+	Name   string
+	Level  int
+	Types  []string
+	Stats  map[string]int
+	HP     int
+	Status string
+	Moves  []Move
+
+	sleepTurns int
+}
+
+// MaxHP returns the Pokemon's HP stat.
+func (p *Pokemon) MaxHP() int {
+	return p.Stats[stats.HP]
+}
+
+func (p *Pokemon) Fainted() bool {
+	return p.HP <= 0
+}
+
+// Speed returns the speed used to order turns; paralysis halves it.
+func (p *Pokemon) Speed() int {
+	if p.Status == Paralysis {
+		return p.Stats[stats.Speed] / 2
+	}
+	return p.Stats[stats.Speed]
+}
+
+func (p *Pokemon) Move(name string) (Move, bool) {
+	i := slices.IndexFunc(p.Moves, func(m Move) bool { return m.Name == name })
+	if i < 0 {
+		return Move{}, false
+	}
+	return p.Moves[i], true
+}
+
+// Battle is a fight between the player's Pokemon and a wild one. All
+// randomness comes from the source it was created with, so a battle replays
+// the same way from the same seed.
+type Battle struct {
+	Player *Pokemon
+	Wild   *Pokemon
+
+	chart       TypeChart
+	rand        *rand.Rand
+	runAttempts int
+}
+
+// ErrOver is returned when a turn is run after a Pokemon fainted.
+var ErrOver = errors.New("the battle is over")
+
+// New starts a battle. rng is the only source of randomness the battle uses.
+func New(player *Pokemon, wild *Pokemon, chart TypeChart, rng *rand.Rand) *Battle {
+	return &Battle{Player: player, Wild: wild, chart: chart, rand: rng}
+}
+
+// Over reports whether either Pokemon has fainted.
+func (b *Battle) Over() bool {
+	return b.Player.Fainted() || b.Wild.Fainted()
+}
+
+// Fight runs a turn in which the player's Pokemon uses a move. The faster
+// Pokemon moves first, unless one of the moves has a higher priority.
+func (b *Battle) Fight(move Move) ([]string, error) {
+	if b.Over() {
+		return nil, ErrOver
+	}
+
+	wildMove := b.wildMove()
+
+	first, firstMove, second, secondMove := b.Player, move, b.Wild, wildMove
+	if b.wildFirst(move, wildMove) {
+		first, firstMove, second, secondMove = b.Wild, wildMove, b.Player, move
+	}
+
+	log, err := b.useMove(first, second, firstMove)
+	if err != nil {
+		return nil, err
+	}
+
+	if !second.Fainted() {
+		more, err := b.useMove(second, first, secondMove)
+		if err != nil {
+			return nil, err
+		}
+		log = append(log, more...)
+	}
+
+	return append(log, b.endTurn()...), nil
+}
+
+// WildTurn runs a turn in which only the wild Pokemon attacks, after the
+// player switched, used an item or threw a ball.
+func (b *Battle) WildTurn() ([]string, error) {
+	if b.Over() {
+		return nil, ErrOver
+	}
+
+	log, err := b.useMove(b.Wild, b.Player, b.wildMove())
+	if err != nil {
+		return nil, err
+	}
+
+	return append(log, b.endTurn()...), nil
+}
+
+// Switch sends out another of the player's Pokemon. Switching out a fainted
+// Pokemon is free, otherwise it takes the player's turn.
+func (b *Battle) Switch(p *Pokemon) ([]string, error) {
+	fainted := b.Player.Fainted()
+	b.Player = p
+
+	log := []string{fmt.Sprintf("Go, %s!", p.Name)}
+	if fainted {
+		return log, nil
+	}
+
+	more, err := b.WildTurn()
+	if err != nil {
+		return nil, err
+	}
+	return append(log, more...), nil
+}
+
+// Run tries to flee with the games' escape formula; every failed attempt
+// makes the next one likelier. A failed attempt takes the player's turn.
+func (b *Battle) Run() (bool, []string, error) {
+	if b.Over() {
+		return false, nil, ErrOver
+	}
+
+	b.runAttempts++
+	playerSpeed, wildSpeed := b.Player.Speed(), b.Wild.Speed()
+
+	odds := 256
+	if wildSpeed > 0 {
+		odds = (playerSpeed*128/wildSpeed + 30*b.runAttempts) % 256
+	}
+	if playerSpeed >= wildSpeed || b.rand.Intn(256) < odds {
+		return true, []string{"Got away safely!"}, nil
+	}
+
+	log, err := b.WildTurn()
+	if err != nil {
+		return false, nil, err
+	}
+	return false, append([]string{"Can't escape!"}, log...), nil
+}
+
+// wildMove picks one of the wild Pokemon's moves at random.
+func (b *Battle) wildMove() Move {
+	if len(b.Wild.Moves) == 0 {
+		return Struggle
+	}
+	return b.Wild.Moves[b.rand.Intn(len(b.Wild.Moves))]
+}
+
+func (b *Battle) wildFirst(playerMove Move, wildMove Move) bool {
+	if playerMove.Priority != wildMove.Priority {
+		return wildMove.Priority > playerMove.Priority
+	}
+
+	playerSpeed, wildSpeed := b.Player.Speed(), b.Wild.Speed()
+	if playerSpeed != wildSpeed {
+		return wildSpeed > playerSpeed
+	}
+	return b.rand.Intn(2) == 0
+}
+
+// useMove makes attacker use move on defender.
+func (b *Battle) useMove(attacker *Pokemon, defender *Pokemon, move Move) ([]string, error) {
+	log := []string{}
+
+	message, canMove := b.checkStatus(attacker)
+	if message != "" {
+		log = append(log, message)
+	}
+	if !canMove {
+		return log, nil
+	}
+
+	log = append(log, fmt.Sprintf("%s used %s!", attacker.Name, move.Name))
+
+	if move.Accuracy > 0 && b.rand.Intn(100) >= move.Accuracy {
+		return append(log, fmt.Sprintf("%s's attack missed!", attacker.Name)), nil
+	}
+
+	effectiveness, err := b.chart.Effectiveness(move.Type, defender.Types...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Immunities hold against status moves too, so thunder-wave can't
+	// paralyze ground types.
+	if effectiveness == 0 && (move.Power > 0 || move.DamageClass == "status" && move.Type != "") {
+		return append(log, fmt.Sprintf("It doesn't affect %s...", defender.Name)), nil
+	}
+
+	if move.DamageClass != "status" && move.Power > 0 {
+
+		critical := b.rand.Intn(critChance) == 0
+		random := 85 + b.rand.Intn(16)
+		damage := Damage(attacker, defender, move, effectiveness, critical, random)
+		defender.HP = max(0, defender.HP-damage)
+
+		if critical {
+			log = append(log, "A critical hit!")
+		}
+		switch {
+		case effectiveness > 1:
+			log = append(log, "It's super effective!")
+		case effectiveness < 1:
+			log = append(log, "It's not very effective...")
+		}
+		log = append(log, fmt.Sprintf("%s took %d damage (%d/%d HP).", defender.Name, damage, defender.HP, defender.MaxHP()))
+
+		if defender.Fainted() {
+			return append(log, fmt.Sprintf("%s fainted!", defender.Name)), nil
+		}
+	}
+
+	// Moves without power that the engine has no effect for do nothing.
+	if message, ok := b.inflict(defender, move); ok {
+		log = append(log, message)
+	} else if move.DamageClass == "status" || move.Power == 0 {
+		log = append(log, "But nothing happened!")
+	}
+
+	return log, nil
+}
+
+// checkStatus checks whether a status condition keeps a Pokemon from moving,
+// returning what happened to it.
+func (b *Battle) checkStatus(p *Pokemon) (string, bool) {
+	switch p.Status {
+	case Sleep:
+		if p.sleepTurns > 0 {
+			p.sleepTurns--
+			return fmt.Sprintf("%s is fast asleep.", p.Name), false
+		}
+		p.Status = ""
+		return fmt.Sprintf("%s woke up!", p.Name), true
+	case Freeze:
+		if b.rand.Intn(5) != 0 {
+			return fmt.Sprintf("%s is frozen solid!", p.Name), false
+		}
+		p.Status = ""
+		return fmt.Sprintf("%s thawed out!", p.Name), true
+	case Paralysis:
+		if b.rand.Intn(4) == 0 {
+			return fmt.Sprintf("%s is paralyzed! It can't move!", p.Name), false
+		}
+	}
+	return "", true
+}
+
+// inflict gives the defender the move's status condition when it has none
+// yet.
+func (b *Battle) inflict(defender *Pokemon, move Move) (string, bool) {
+	if !slices.Contains(statuses, move.Ailment) || defender.Status != "" || defender.Fainted() {
+		return "", false
+	}
+	if move.AilmentChance > 0 && b.rand.Intn(100) >= move.AilmentChance {
+		return "", false
+	}
+	if move.AilmentChance == 0 && move.DamageClass != "status" {
+		return "", false
+	}
+
+	defender.Status = move.Ailment
+	if move.Ailment == Sleep {
+		defender.sleepTurns = 1 + b.rand.Intn(3)
+	}
+
+	return fmt.Sprintf("%s is now %s!", defender.Name, describeStatus(move.Ailment)), true
+}
+
+func describeStatus(status string) string {
+	switch status {
+	case Paralysis:
+		return "paralyzed"
+	case Sleep:
+		return "asleep"
+	case Poison:
+		return "poisoned"
+	case Burn:
+		return "burned"
+	case Freeze:
+		return "frozen"
+	}
+	return status
+}
+
+// endTurn deals poison and burn damage.
+func (b *Battle) endTurn() []string {
+	log := []string{}
+
+	for _, p := range []*Pokemon{b.Player, b.Wild} {
+		if p.Fainted() {
+			continue
+		}
+
+		fraction := 0
+		switch p.Status {
+		case Poison:
+			fraction = 8
+		case Burn:
+			fraction = 16
+		}
+		if fraction == 0 {
+			continue
+		}
+
+		damage := max(1, p.MaxHP()/fraction)
+		p.HP = max(0, p.HP-damage)
+		log = append(log, fmt.Sprintf("%s is hurt by its %s (%d/%d HP).", p.Name, p.Status, p.HP, p.MaxHP()))
+		if p.Fainted() {
+			log = append(log, fmt.Sprintf("%s fainted!", p.Name))
+		}
+	}
+
+	return log
+}
+
+// Damage calculates the damage of a move with the main-series formula:
+//
+//	((2*Level/5 + 2) * Power * A/D) / 50 + 2
+//
+// times 1.5 for a critical hit, random/100 with random from 85 to 100, 1.5
+// for a move of the attacker's own type (STAB), the type effectiveness, and
+// 0.5 for a physical move of a burned attacker, rounding down after every
+// step.
+func Damage(attacker *Pokemon, defender *Pokemon, move Move, effectiveness float64, critical bool, random int) int {
+	a, d := attacker.Stats[stats.Attack], defender.Stats[stats.Defense]
+	if move.DamageClass == "special" {
+		a, d = attacker.Stats[stats.SpecialAttack], defender.Stats[stats.SpecialDefense]
+	}
+	d = max(1, d)
+
+	damage := (2*attacker.Level/5+2)*move.Power*a/d/50 + 2
+
+	if critical {
+		damage = damage * 3 / 2
+	}
+	damage = damage * random / 100
+	if slices.Contains(attacker.Types, move.Type) {
+		damage = damage * 3 / 2
+	}
+	damage = int(float64(damage) * effectiveness)
+	if attacker.Status == Burn && move.DamageClass == "physical" {
+		damage /= 2
+	}
+
+	if effectiveness > 0 {
+		damage = max(1, damage)
+	}
+	return damage
+}
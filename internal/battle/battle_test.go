@@ -0,0 +1,236 @@
+package battle
+
+import (
+	"fmt"
+	"math/rand"
+	"pokedex/internal/stats"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testChart map[string]map[string]float64
+
+func (c testChart) Effectiveness(attack string, defenders ...string) (float64, error) {
+	multiplier := 1.0
+	for _, defender := range defenders {
+		if m, ok := c[attack][defender]; ok {
+			multiplier *= m
+		}
+	}
+	return multiplier, nil
+}
+
+var chart = testChart{
+	"electric": {"water": 2, "ground": 0, "electric": 0.5},
+	"water":    {"ground": 2, "water": 0.5},
+	"ice":      {"dragon": 2, "ground": 2},
+}
+
+var (
+	thunderShock = Move{Name: "thunder-shock", Type: "electric", DamageClass: "special", Power: 40, Accuracy: 100, Ailment: Paralysis, AilmentChance: 10}
+	thunderWave  = Move{Name: "thunder-wave", Type: "electric", DamageClass: "status", Accuracy: 90, Ailment: Paralysis}
+	quickAttack  = Move{Name: "quick-attack", Type: "normal", DamageClass: "physical", Power: 40, Accuracy: 100, Priority: 1}
+	waterGun     = Move{Name: "water-gun", Type: "water", DamageClass: "special", Power: 40, Accuracy: 100}
+	tackle       = Move{Name: "tackle", Type: "normal", DamageClass: "physical", Power: 40, Accuracy: 100}
+)
+
+func pikachu() *Pokemon {
+	return &Pokemon{
+		Name:  "pikachu",
+		Level: 10,
+		Types: []string{"electric"},
+		Stats: map[string]int{stats.HP: 30, stats.Attack: 17, stats.Defense: 13, stats.SpecialAttack: 16, stats.SpecialDefense: 16, stats.Speed: 26},
+		HP:    30,
+		Moves: []Move{thunderShock, thunderWave, quickAttack},
+	}
+}
+
+func squirtle() *Pokemon {
+	return &Pokemon{
+		Name:  "squirtle",
+		Level: 10,
+		Types: []string{"water"},
+		Stats: map[string]int{stats.HP: 31, stats.Attack: 14, stats.Defense: 18, stats.SpecialAttack: 15, stats.SpecialDefense: 17, stats.Speed: 14},
+		HP:    31,
+		Moves: []Move{waterGun, tackle},
+	}
+}
+
+func TestDamage(t *testing.T) {
+	glaceon := &Pokemon{Level: 75, Types: []string{"ice"}, Stats: map[string]int{stats.Attack: 123}}
+	garchomp := &Pokemon{Types: []string{"dragon", "ground"}, Stats: map[string]int{stats.Defense: 163}}
+	iceFang := Move{Name: "ice-fang", Type: "ice", DamageClass: "physical", Power: 65}
+
+	cases := []struct {
+		random   int
+		critical bool
+		status   string
+		expected int
+	}{
+		{random: 85, expected: 168},
+		{random: 100, expected: 196},
+		{random: 100, critical: true, expected: 292},
+		{random: 100, status: Burn, expected: 98},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			glaceon.Status = c.status
+			damage := Damage(glaceon, garchomp, iceFang, 4, c.critical, c.random)
+			if damage != c.expected {
+				t.Errorf("expected %d, got %d", c.expected, damage)
+				return
+			}
+		})
+	}
+}
+
+func TestSameSeedSameBattle(t *testing.T) {
+	logs := [][]string{}
+
+	for range 2 {
+		b := New(pikachu(), squirtle(), chart, rand.New(rand.NewSource(7)))
+		log := []string{}
+		for !b.Over() {
+			turn, err := b.Fight(thunderShock)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			log = append(log, turn...)
+		}
+		logs = append(logs, log)
+	}
+
+	if !reflect.DeepEqual(logs[0], logs[1]) {
+		t.Errorf("expected the same battle twice, got\n%v\n%v", logs[0], logs[1])
+	}
+	if !strings.HasSuffix(logs[0][len(logs[0])-1], "fainted!") {
+		t.Errorf("expected the battle to end with a faint, got %q", logs[0][len(logs[0])-1])
+	}
+}
+
+func TestTurnOrder(t *testing.T) {
+	cases := []struct {
+		player   *Pokemon
+		move     Move
+		expected string
+	}{
+		{
+			player:   pikachu(),
+			move:     thunderShock,
+			expected: "pikachu used thunder-shock!",
+		},
+		{
+			player:   &Pokemon{Name: "slowpoke", Level: 10, Stats: map[string]int{stats.HP: 40, stats.Speed: 5}, HP: 40},
+			move:     tackle,
+			expected: "squirtle used",
+		},
+		{
+			player:   &Pokemon{Name: "slowpoke", Level: 10, Stats: map[string]int{stats.HP: 40, stats.Speed: 5}, HP: 40},
+			move:     quickAttack,
+			expected: "slowpoke used quick-attack!",
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			b := New(c.player, squirtle(), chart, rand.New(rand.NewSource(1)))
+			log, err := b.Fight(c.move)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if !strings.HasPrefix(log[0], c.expected) {
+				t.Errorf("expected the turn to start with %q, got %q", c.expected, log[0])
+				return
+			}
+		})
+	}
+}
+
+func TestImmunity(t *testing.T) {
+	geodude := &Pokemon{Name: "geodude", Level: 10, Types: []string{"ground"}, Stats: map[string]int{stats.HP: 30, stats.Speed: 1}, HP: 30}
+	b := New(pikachu(), geodude, chart, rand.New(rand.NewSource(1)))
+
+	log, err := b.Fight(thunderShock)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if geodude.HP != 30 || log[1] != "It doesn't affect geodude..." {
+		t.Errorf("expected no damage, got %d HP and %v", geodude.HP, log)
+	}
+}
+
+func TestStatusMove(t *testing.T) {
+	wild := squirtle()
+	wild.Moves = nil
+	player := pikachu()
+	player.Moves = []Move{{Name: "sure-wave", Type: "electric", DamageClass: "status", Ailment: Paralysis}}
+
+	b := New(player, wild, chart, rand.New(rand.NewSource(1)))
+	_, err := b.Fight(player.Moves[0])
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if wild.Status != Paralysis {
+		t.Errorf("expected squirtle to be paralyzed, got %q", wild.Status)
+	}
+	if wild.Speed() != 7 {
+		t.Errorf("expected paralysis to halve speed to 7, got %d", wild.Speed())
+	}
+}
+
+func TestNoEffect(t *testing.T) {
+	cases := []struct {
+		move     Move
+		expected string
+	}{
+		{
+			move:     Move{Name: "thunder-wave", Type: "electric", DamageClass: "status", Ailment: Paralysis},
+			expected: "It doesn't affect geodude...",
+		},
+		{
+			move:     Move{Name: "seismic-toss", Type: "fighting", DamageClass: "physical"},
+			expected: "But nothing happened!",
+		},
+		{
+			move:     Move{Name: "splash", Type: "normal", DamageClass: "status"},
+			expected: "But nothing happened!",
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			geodude := &Pokemon{Name: "geodude", Level: 10, Types: []string{"ground"}, Stats: map[string]int{stats.HP: 30, stats.Speed: 1}, HP: 30}
+			b := New(pikachu(), geodude, chart, rand.New(rand.NewSource(1)))
+
+			log, err := b.Fight(c.move)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if len(log) < 2 || log[1] != c.expected {
+				t.Errorf("expected %q after the move, got %v", c.expected, log)
+			}
+			if geodude.Status != "" || geodude.HP != 30 {
+				t.Errorf("expected geodude to be unharmed, got %d HP and status %q", geodude.HP, geodude.Status)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	b := New(pikachu(), squirtle(), chart, rand.New(rand.NewSource(1)))
+	escaped, _, err := b.Run()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if !escaped {
+		t.Errorf("expected the faster pokemon to always escape")
+	}
+}
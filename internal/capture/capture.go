@@ -0,0 +1,75 @@
+package capture
+
+import "math"
+
+// Shakes is the number of shake checks a ball has to pass to catch a Pokemon.
+const Shakes = 4
+
+// Attempt describes one Poke Ball thrown at a wild Pokemon.
+type Attempt struct {
+	// CaptureRate is the species' capture rate, from 3 for legendaries up to
+	// 255 for the easiest Pokemon.
+	CaptureRate int
+	MaxHP       int
+	HP          int
+	// Ball is the ball's catch rate multiplier, e.g. 1.5 for a Great Ball.
+	Ball float64
+	// Status is the non-volatile status of the target, e.g. "sleep", or ""
+	// when it has none.
+	Status string
+}
+
+// StatusBonus returns the catch rate multiplier of a status condition.
+func StatusBonus(status string) float64 {
+	switch status {
+	case "sleep", "freeze":
+		return 2
+	case "paralysis", "poison", "burn":
+		return 1.5
+	}
+	return 1
+}
+
+// ModifiedRate returns the modified catch rate "a" of the generation III and
+// IV games. A rate of 255 or more always catches.
+func (a Attempt) ModifiedRate() int {
+	maxHP, hp := a.MaxHP, a.HP
+	if maxHP < 1 {
+		maxHP = 1
+	}
+	hp = max(1, min(hp, maxHP))
+
+	rate := float64((3*maxHP-2*hp)*a.CaptureRate) * a.Ball / float64(3*maxHP) * StatusBonus(a.Status)
+	return max(1, int(rate))
+}
+
+// ShakeThreshold returns "b": each shake check passes when a random number
+// below 65536 is less than it.
+func (a Attempt) ShakeThreshold() int {
+	rate := a.ModifiedRate()
+	if rate >= 255 {
+		return 65536
+	}
+	return int(1048560 / math.Sqrt(math.Sqrt(16711680/float64(rate))))
+}
+
+// Chance returns the probability that the attempt catches the Pokemon.
+func (a Attempt) Chance() float64 {
+	return math.Pow(math.Min(1, float64(a.ShakeThreshold())/65536), Shakes)
+}
+
+// Throw runs the shake checks with intn, which returns a random number in
+// [0, n) like math/rand.Intn. It returns how many checks passed; the Pokemon
+// is caught when all Shakes of them did.
+func (a Attempt) Throw(intn func(n int) int) (shakes int, caught bool) {
+	threshold := a.ShakeThreshold()
+
+	for shakes < Shakes {
+		if intn(65536) >= threshold {
+			return shakes, false
+		}
+		shakes++
+	}
+
+	return shakes, true
+}
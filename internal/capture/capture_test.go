@@ -0,0 +1,118 @@
+package capture
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestModifiedRate(t *testing.T) {
+	cases := []struct {
+		attempt  Attempt
+		expected int
+	}{
+		{
+			attempt:  Attempt{CaptureRate: 190, MaxHP: 35, HP: 35, Ball: 1},
+			expected: 63,
+		},
+		{
+			attempt:  Attempt{CaptureRate: 190, MaxHP: 35, HP: 1, Ball: 1},
+			expected: 186,
+		},
+		{
+			attempt:  Attempt{CaptureRate: 45, MaxHP: 100, HP: 100, Ball: 2, Status: "sleep"},
+			expected: 60,
+		},
+		{
+			attempt:  Attempt{CaptureRate: 3, MaxHP: 200, HP: 200, Ball: 1.5, Status: "paralysis"},
+			expected: 2,
+		},
+		{
+			attempt:  Attempt{CaptureRate: 3, MaxHP: 200, HP: 200, Ball: 255},
+			expected: 255,
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			rate := c.attempt.ModifiedRate()
+			if rate != c.expected {
+				t.Errorf("expected %d, got %d", c.expected, rate)
+				return
+			}
+		})
+	}
+}
+
+func TestShakeThreshold(t *testing.T) {
+	cases := []struct {
+		attempt  Attempt
+		expected int
+	}{
+		{
+			attempt:  Attempt{CaptureRate: 255, MaxHP: 10, HP: 1, Ball: 2},
+			expected: 65536,
+		},
+		{
+			attempt:  Attempt{CaptureRate: 190, MaxHP: 35, HP: 35, Ball: 1},
+			expected: 46203,
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			threshold := c.attempt.ShakeThreshold()
+			if threshold != c.expected {
+				t.Errorf("expected %d, got %d", c.expected, threshold)
+				return
+			}
+		})
+	}
+}
+
+func TestThrow(t *testing.T) {
+	attempt := Attempt{CaptureRate: 190, MaxHP: 35, HP: 35, Ball: 1}
+
+	cases := []struct {
+		rolls    []int
+		shakes   int
+		expected bool
+	}{
+		{
+			rolls:    []int{0, 0, 0, 0},
+			shakes:   4,
+			expected: true,
+		},
+		{
+			rolls:    []int{0, 0, 65535},
+			shakes:   2,
+			expected: false,
+		},
+		{
+			rolls:    []int{46203},
+			shakes:   0,
+			expected: false,
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			rolls := c.rolls
+			shakes, caught := attempt.Throw(func(n int) int {
+				roll := rolls[0]
+				rolls = rolls[1:]
+				return roll
+			})
+			if shakes != c.shakes || caught != c.expected {
+				t.Errorf("expected %d shakes and caught %v, got %d and %v", c.shakes, c.expected, shakes, caught)
+				return
+			}
+		})
+	}
+}
+
+func TestChance(t *testing.T) {
+	attempt := Attempt{CaptureRate: 255, MaxHP: 10, HP: 1, Ball: 2}
+	if chance := attempt.Chance(); chance != 1 {
+		t.Errorf("expected a certain catch, got %v", chance)
+	}
+}
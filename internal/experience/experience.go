@@ -0,0 +1,80 @@
+package experience
+
+import "fmt"
+
+// Growth rates as the API names them.
+const (
+	Slow        = "slow"
+	Medium      = "medium"
+	Fast        = "fast"
+	MediumSlow  = "medium-slow"
+	Erratic     = "slow-then-very-fast"
+	Fluctuating = "fast-then-very-slow"
+)
+
+// MaxLevel is the highest level a Pokemon can reach.
+const MaxLevel = 100
+
+// ForLevel returns the total experience a Pokemon of a growth rate needs to
+// reach a level.
+func ForLevel(rate string, level int) (int, error) {
+	n := min(level, MaxLevel)
+	if n <= 1 {
+		return 0, nil
+	}
+	cube := n * n * n
+
+	switch rate {
+	case Fast:
+		return 4 * cube / 5, nil
+	case Medium:
+		return cube, nil
+	case MediumSlow:
+		return 6*cube/5 - 15*n*n + 100*n - 140, nil
+	case Slow:
+		return 5 * cube / 4, nil
+	case Erratic:
+		switch {
+		case n < 50:
+			return cube * (100 - n) / 50, nil
+		case n < 68:
+			return cube * (150 - n) / 100, nil
+		case n < 98:
+			return cube * ((1911 - 10*n) / 3) / 500, nil
+		}
+		return cube * (160 - n) / 100, nil
+	case Fluctuating:
+		switch {
+		case n < 15:
+			return cube * ((n+1)/3 + 24) / 50, nil
+		case n < 36:
+			return cube * (n + 14) / 50, nil
+		}
+		return cube * (n/2 + 32) / 50, nil
+	}
+
+	return 0, fmt.Errorf("unknown growth rate %q", rate)
+}
+
+// Level returns the level a Pokemon of a growth rate has with a total amount
+// of experience.
+func Level(rate string, experience int) (int, error) {
+	level := 1
+	for level < MaxLevel {
+		next, err := ForLevel(rate, level+1)
+		if err != nil {
+			return 0, err
+		}
+		if experience < next {
+			break
+		}
+		level++
+	}
+	return level, nil
+}
+
+// Gain returns the experience a Pokemon earns for defeating or catching a
+// wild Pokemon with a base experience yield at a level.
+func Gain(baseExperience int, level int) int {
+	return max(1, baseExperience*level/7)
+}
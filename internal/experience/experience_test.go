@@ -0,0 +1,77 @@
+package experience
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestForLevel(t *testing.T) {
+	cases := []struct {
+		rate     string
+		level    int
+		expected int
+	}{
+		{rate: Medium, level: 1, expected: 0},
+		{rate: Medium, level: 5, expected: 125},
+		{rate: MediumSlow, level: 2, expected: 9},
+		{rate: MediumSlow, level: 100, expected: 1059860},
+		{rate: Fast, level: 100, expected: 800000},
+		{rate: Slow, level: 100, expected: 1250000},
+		{rate: Erratic, level: 2, expected: 15},
+		{rate: Erratic, level: 60, expected: 194400},
+		{rate: Erratic, level: 80, expected: 378880},
+		{rate: Erratic, level: 100, expected: 600000},
+		{rate: Fluctuating, level: 2, expected: 4},
+		{rate: Fluctuating, level: 20, expected: 5440},
+		{rate: Fluctuating, level: 100, expected: 1640000},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			actual, err := ForLevel(c.rate, c.level)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if actual != c.expected {
+				t.Errorf("expected %d, got %d", c.expected, actual)
+				return
+			}
+		})
+	}
+}
+
+func TestLevel(t *testing.T) {
+	cases := []struct {
+		rate       string
+		experience int
+		expected   int
+	}{
+		{rate: Medium, experience: 0, expected: 1},
+		{rate: Medium, experience: 124, expected: 4},
+		{rate: Medium, experience: 125, expected: 5},
+		{rate: Medium, experience: 5000000, expected: 100},
+		{rate: Fluctuating, experience: 5440, expected: 20},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			actual, err := Level(c.rate, c.experience)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if actual != c.expected {
+				t.Errorf("expected %d, got %d", c.expected, actual)
+				return
+			}
+		})
+	}
+}
+
+func TestUnknownRate(t *testing.T) {
+	_, err := ForLevel("very-slow", 10)
+	if err == nil {
+		t.Errorf("expected an error for an unknown growth rate")
+	}
+}
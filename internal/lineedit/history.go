@@ -0,0 +1,107 @@
+package lineedit
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// History keeps previously entered lines, oldest first, and optionally
+// persists them to a file so they survive between sessions.
+type History struct {
+	entries []string
+	max     int
+	path    string
+}
+
+func NewHistory(max int) *History {
+	return &History{
+		entries: []string{},
+		max:     max,
+	}
+}
+
+// LoadHistory reads the history file at path. A missing file is not an
+// error, it is created on the first Add.
+func LoadHistory(path string, max int) (*History, error) {
+	history := NewHistory(max)
+	history.path = path
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return history, nil
+	}
+	if err != nil {
+		return history, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		history.add(scanner.Text())
+	}
+
+	return history, scanner.Err()
+}
+
+// Add records line, skipping blanks and immediate repeats, and saves the
+// history if it was loaded from a file.
+func (h *History) Add(line string) error {
+	if !h.add(line) || h.path == "" {
+		return nil
+	}
+	return h.save()
+}
+
+func (h *History) add(line string) bool {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return false
+	}
+	if len(h.entries) > 0 && h.entries[len(h.entries)-1] == line {
+		return false
+	}
+
+	h.entries = append(h.entries, line)
+	if h.max > 0 && len(h.entries) > h.max {
+		h.entries = h.entries[len(h.entries)-h.max:]
+	}
+
+	return true
+}
+
+func (h *History) save() error {
+	err := os.MkdirAll(filepath.Dir(h.path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(h.path, []byte(strings.Join(h.entries, "\n")+"\n"), 0o600)
+}
+
+func (h *History) Len() int {
+	return len(h.entries)
+}
+
+// At returns the i-th entry, oldest first.
+func (h *History) At(i int) string {
+	return h.entries[i]
+}
+
+// Search looks backwards from index before for an entry containing query and
+// returns its index, or -1 if there is none.
+func (h *History) Search(query string, before int) int {
+	if before > len(h.entries) {
+		before = len(h.entries)
+	}
+
+	for i := before - 1; i >= 0; i-- {
+		if strings.Contains(h.entries[i], query) {
+			return i
+		}
+	}
+
+	return -1
+}
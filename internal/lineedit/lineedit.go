@@ -0,0 +1,402 @@
+package lineedit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ErrInterrupted is returned by ReadLine when the user presses Ctrl-C.
+var ErrInterrupted = errors.New("interrupted")
+
+const (
+	keyCtrlA     = 1
+	keyCtrlB     = 2
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlF     = 6
+	keyCtrlG     = 7
+	keyBackspace = 8
+	keyTab       = 9
+	keyCtrlK     = 11
+	keyCtrlL     = 12
+	keyEnter     = 13
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlR     = 18
+	keyCtrlU     = 21
+	keyCtrlW     = 23
+	keyEscape    = 27
+	keyDelete    = 127
+)
+
+// Editor reads lines from a terminal with cursor movement, history and tab
+// completion.
+type Editor struct {
+	Prompt  string
+	History *History
+	// Complete returns the candidates for the word under the cursor given the
+	// line up to the cursor. Candidates not matching the word are ignored.
+	Complete func(before string) []string
+
+	in  *os.File
+	r   *bufio.Reader
+	out io.Writer
+}
+
+func New(in *os.File, out io.Writer) *Editor {
+	return &Editor{
+		History: NewHistory(0),
+		in:      in,
+		r:       bufio.NewReader(in),
+		out:     out,
+	}
+}
+
+// ReadLine puts the terminal in raw mode and reads one line. It returns
+// io.EOF on Ctrl-D at an empty line and ErrInterrupted on Ctrl-C.
+func (e *Editor) ReadLine() (string, error) {
+	fd := int(e.in.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return "", err
+	}
+	defer term.Restore(fd, state)
+
+	line, err := e.readLine(e.r, e.out)
+	if err == nil {
+		e.History.Add(line)
+	}
+	return line, err
+}
+
+// lineState is the line being edited.
+type lineState struct {
+	buf []rune
+	pos int
+
+	// historyIndex is the history entry shown, History.Len() for the line
+	// being typed, which is kept in pending while browsing.
+	historyIndex int
+	pending      []rune
+
+	searching    bool
+	query        []rune
+	searchIndex  int
+	beforeSearch []rune
+}
+
+func (e *Editor) readLine(r *bufio.Reader, w io.Writer) (string, error) {
+	s := &lineState{buf: []rune{}, historyIndex: e.History.Len()}
+	e.refresh(w, s)
+
+	for {
+		key, _, err := r.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		if s.searching {
+			done := e.searchKey(w, s, key)
+			if !done {
+				continue
+			}
+			if key == keyCtrlG {
+				e.refresh(w, s)
+				continue
+			}
+		}
+
+		switch key {
+		case keyEnter, '\n':
+			fmt.Fprint(w, "\r\n")
+			return string(s.buf), nil
+		case keyCtrlC:
+			fmt.Fprint(w, "^C\r\n")
+			return "", ErrInterrupted
+		case keyCtrlD:
+			if len(s.buf) == 0 {
+				fmt.Fprint(w, "\r\n")
+				return "", io.EOF
+			}
+			s.deleteForward()
+		case keyCtrlA:
+			s.pos = 0
+		case keyCtrlE:
+			s.pos = len(s.buf)
+		case keyCtrlB:
+			s.left()
+		case keyCtrlF:
+			s.right()
+		case keyCtrlP:
+			e.historyPrev(s)
+		case keyCtrlN:
+			e.historyNext(s)
+		case keyCtrlK:
+			s.buf = s.buf[:s.pos]
+		case keyCtrlU:
+			s.buf = s.buf[s.pos:]
+			s.pos = 0
+		case keyCtrlW:
+			s.deleteWord()
+		case keyBackspace, keyDelete:
+			s.backspace()
+		case keyTab:
+			e.complete(w, s)
+		case keyCtrlR:
+			s.searching = true
+			s.query = []rune{}
+			s.searchIndex = e.History.Len()
+			s.beforeSearch = append([]rune{}, s.buf...)
+			e.refreshSearch(w, s)
+			continue
+		case keyEscape:
+			e.escape(r, s)
+		case keyCtrlL:
+			fmt.Fprint(w, "\x1b[H\x1b[2J")
+		default:
+			if key >= ' ' {
+				s.insert(key)
+			}
+		}
+
+		e.refresh(w, s)
+	}
+}
+
+// escape handles the ANSI sequences sent by arrow, home, end and delete keys.
+func (e *Editor) escape(r *bufio.Reader, s *lineState) {
+	next, _, err := r.ReadRune()
+	if err != nil || (next != '[' && next != 'O') {
+		return
+	}
+
+	code, _, err := r.ReadRune()
+	if err != nil {
+		return
+	}
+
+	if code >= '0' && code <= '9' {
+		tilde, _, err := r.ReadRune()
+		if err != nil || tilde != '~' {
+			return
+		}
+		switch code {
+		case '1', '7':
+			s.pos = 0
+		case '4', '8':
+			s.pos = len(s.buf)
+		case '3':
+			s.deleteForward()
+		}
+		return
+	}
+
+	switch code {
+	case 'A':
+		e.historyPrev(s)
+	case 'B':
+		e.historyNext(s)
+	case 'C':
+		s.right()
+	case 'D':
+		s.left()
+	case 'H':
+		s.pos = 0
+	case 'F':
+		s.pos = len(s.buf)
+	}
+}
+
+// searchKey handles a key while in reverse search and reports whether the
+// search ended. Keys that end the search are then handled as usual, except
+// Ctrl-G which cancels it.
+func (e *Editor) searchKey(w io.Writer, s *lineState, key rune) bool {
+	switch {
+	case key == keyCtrlR:
+		if found := e.History.Search(string(s.query), s.searchIndex); found >= 0 {
+			s.searchIndex = found
+			s.setBuf([]rune(e.History.At(found)))
+		}
+	case key == keyBackspace || key == keyDelete:
+		if len(s.query) > 0 {
+			s.query = s.query[:len(s.query)-1]
+			e.searchFrom(s, e.History.Len())
+		}
+	case key == keyCtrlG:
+		s.searching = false
+		s.setBuf(s.beforeSearch)
+		return true
+	case key >= ' ':
+		s.query = append(s.query, key)
+		e.searchFrom(s, s.searchIndex+1)
+	default:
+		s.searching = false
+		return true
+	}
+
+	e.refreshSearch(w, s)
+	return false
+}
+
+func (e *Editor) searchFrom(s *lineState, before int) {
+	found := e.History.Search(string(s.query), before)
+	if found >= 0 {
+		s.searchIndex = found
+		s.setBuf([]rune(e.History.At(found)))
+	}
+}
+
+func (e *Editor) historyPrev(s *lineState) {
+	if s.historyIndex == 0 {
+		return
+	}
+	if s.historyIndex == e.History.Len() {
+		s.pending = append([]rune{}, s.buf...)
+	}
+	s.historyIndex--
+	s.setBuf([]rune(e.History.At(s.historyIndex)))
+}
+
+func (e *Editor) historyNext(s *lineState) {
+	if s.historyIndex >= e.History.Len() {
+		return
+	}
+	s.historyIndex++
+	if s.historyIndex == e.History.Len() {
+		s.setBuf(s.pending)
+		return
+	}
+	s.setBuf([]rune(e.History.At(s.historyIndex)))
+}
+
+// complete extends the word under the cursor to the longest prefix shared by
+// the matching candidates, listing them when it cannot extend any further.
+func (e *Editor) complete(w io.Writer, s *lineState) {
+	if e.Complete == nil {
+		return
+	}
+
+	before := string(s.buf[:s.pos])
+	word := before[strings.LastIndexAny(before, " \t")+1:]
+
+	matches := Matches(e.Complete(before), word)
+	if len(matches) == 0 {
+		return
+	}
+
+	insert := []rune(CommonPrefix(matches)[len(word):])
+	if len(matches) == 1 {
+		insert = append(insert, ' ')
+	}
+
+	if len(insert) > 0 {
+		for _, r := range insert {
+			s.insert(r)
+		}
+		return
+	}
+
+	fmt.Fprint(w, "\r\n"+strings.Join(matches, "  ")+"\r\n")
+}
+
+func (e *Editor) refresh(w io.Writer, s *lineState) {
+	fmt.Fprintf(w, "\r%s%s\x1b[K", e.Prompt, string(s.buf))
+	if back := len(s.buf) - s.pos; back > 0 {
+		fmt.Fprintf(w, "\x1b[%dD", back)
+	}
+}
+
+func (e *Editor) refreshSearch(w io.Writer, s *lineState) {
+	fmt.Fprintf(w, "\r(reverse-i-search)`%s': %s\x1b[K", string(s.query), string(s.buf))
+}
+
+func (s *lineState) setBuf(buf []rune) {
+	s.buf = append([]rune{}, buf...)
+	s.pos = len(s.buf)
+}
+
+func (s *lineState) insert(r rune) {
+	s.buf = append(s.buf, 0)
+	copy(s.buf[s.pos+1:], s.buf[s.pos:])
+	s.buf[s.pos] = r
+	s.pos++
+}
+
+func (s *lineState) backspace() {
+	if s.pos == 0 {
+		return
+	}
+	s.buf = append(s.buf[:s.pos-1], s.buf[s.pos:]...)
+	s.pos--
+}
+
+func (s *lineState) deleteForward() {
+	if s.pos >= len(s.buf) {
+		return
+	}
+	s.buf = append(s.buf[:s.pos], s.buf[s.pos+1:]...)
+}
+
+func (s *lineState) deleteWord() {
+	start := s.pos
+	for start > 0 && s.buf[start-1] == ' ' {
+		start--
+	}
+	for start > 0 && s.buf[start-1] != ' ' {
+		start--
+	}
+	s.buf = append(s.buf[:start], s.buf[s.pos:]...)
+	s.pos = start
+}
+
+func (s *lineState) left() {
+	if s.pos > 0 {
+		s.pos--
+	}
+}
+
+func (s *lineState) right() {
+	if s.pos < len(s.buf) {
+		s.pos++
+	}
+}
+
+// Matches returns the sorted, de-duplicated candidates starting with prefix.
+func Matches(candidates []string, prefix string) []string {
+	seen := map[string]bool{}
+	matches := []string{}
+
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) && !seen[candidate] {
+			seen[candidate] = true
+			matches = append(matches, candidate)
+		}
+	}
+
+	sort.Strings(matches)
+	return matches
+}
+
+// CommonPrefix returns the longest prefix shared by all words.
+func CommonPrefix(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+
+	prefix := words[0]
+	for _, word := range words[1:] {
+		for !strings.HasPrefix(word, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+
+	return prefix
+}
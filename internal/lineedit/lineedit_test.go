@@ -0,0 +1,164 @@
+package lineedit
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadLine(t *testing.T) {
+	cases := []struct {
+		input    string
+		history  []string
+		expected string
+	}{
+		{
+			input:    "map\r",
+			expected: "map",
+		},
+		{
+			input:    "catc\x7fch pikachu\r",
+			expected: "catch pikachu",
+		},
+		{
+			input:    "atch pikachu\x01c\r",
+			expected: "catch pikachu",
+		},
+		{
+			input:    "catch pikachu\x1b[D\x1b[D\x1b[3~\x1b[C!\r",
+			expected: "catch pikacu!",
+		},
+		{
+			input:    "catch pikachu\x17bulbasaur\r",
+			expected: "catch bulbasaur",
+		},
+		{
+			input:    "\x1b[A\x1b[A\r",
+			history:  []string{"map", "mapb"},
+			expected: "map",
+		},
+		{
+			input:    "ex\x1b[A\x1b[B\r",
+			history:  []string{"map"},
+			expected: "ex",
+		},
+		{
+			input:    "\x12pi\r",
+			history:  []string{"catch pikachu", "map", "explore pastoria-city-area"},
+			expected: "catch pikachu",
+		},
+		{
+			input:    "\x12a\x12\x12\x06\r",
+			history:  []string{"catch pikachu", "map", "explore pastoria-city-area"},
+			expected: "catch pikachu",
+		},
+		{
+			input:    "insp\x12map\x07\r",
+			history:  []string{"map"},
+			expected: "insp",
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			editor := &Editor{History: NewHistory(0)}
+			for _, line := range c.history {
+				editor.History.Add(line)
+			}
+
+			line, err := editor.readLine(bufio.NewReader(strings.NewReader(c.input)), io.Discard)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if line != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, line)
+				return
+			}
+		})
+	}
+}
+
+func TestReadLineEOF(t *testing.T) {
+	editor := &Editor{History: NewHistory(0)}
+
+	_, err := editor.readLine(bufio.NewReader(strings.NewReader("\x04")), io.Discard)
+	if err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+		return
+	}
+}
+
+func TestComplete(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    "ca\t",
+			expected: "catch ",
+		},
+		{
+			input:    "catch p\t",
+			expected: "catch pi",
+		},
+		{
+			input:    "catch pik\t",
+			expected: "catch pikachu ",
+		},
+		{
+			input:    "catch z\t",
+			expected: "catch z",
+		},
+	}
+
+	complete := func(before string) []string {
+		if !strings.Contains(before, " ") {
+			return []string{"catch", "explore", "map", "mapb"}
+		}
+		return []string{"pichu", "pikachu", "bulbasaur"}
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			editor := &Editor{History: NewHistory(0), Complete: complete}
+
+			line, err := editor.readLine(bufio.NewReader(strings.NewReader(c.input+"\r")), io.Discard)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if line != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, line)
+				return
+			}
+		})
+	}
+}
+
+func TestHistoryPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+
+	history, err := LoadHistory(path, 2)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	history.Add("map")
+	history.Add("map")
+	history.Add("explore eterna-forest-area")
+	history.Add("catch budew")
+
+	loaded, err := LoadHistory(path, 2)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if loaded.Len() != 2 || loaded.At(0) != "explore eterna-forest-area" || loaded.At(1) != "catch budew" {
+		t.Errorf("expected the last two entries, got %v", loaded.entries)
+		return
+	}
+}
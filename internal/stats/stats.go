@@ -0,0 +1,77 @@
+package stats
+
+// Stat names as the API spells them.
+const (
+	HP             = "hp"
+	Attack         = "attack"
+	Defense        = "defense"
+	SpecialAttack  = "special-attack"
+	SpecialDefense = "special-defense"
+	Speed          = "speed"
+)
+
+const (
+	MaxLevel = 100
+	MaxIV    = 31
+	// MaxEV is the most effort values a single stat can hold.
+	MaxEV = 252
+)
+
+// Nature raises one stat by 10% and lowers another by 10%. Neutral natures
+// leave both empty, or name the same stat twice.
+type Nature struct {
+	Name      string
+	Increased string
+	Decreased string
+}
+
+// percent returns the nature's effect on a stat in percent.
+func (n Nature) percent(stat string) int {
+	switch {
+	case n.Increased == n.Decreased:
+		return 100
+	case stat == n.Increased:
+		return 110
+	case stat == n.Decreased:
+		return 90
+	}
+	return 100
+}
+
+// Multiplier returns the nature's effect on a stat, e.g. 1.1 for the stat it
+// raises.
+func (n Nature) Multiplier(stat string) float64 {
+	return float64(n.percent(stat)) / 100
+}
+
+// Calc returns the value of a stat at a level with the main-series formulas:
+//
+//	HP    = (2*Base + IV + EV/4) * Level/100 + Level + 10
+//	Other = ((2*Base + IV + EV/4) * Level/100 + 5) * Nature
+//
+// rounding down after every step.
+func Calc(stat string, base, iv, ev, level int, nature Nature) int {
+	core := (2*base + iv + ev/4) * level / 100
+
+	if stat == HP {
+		// Shedinja always has exactly 1 HP.
+		if base == 1 {
+			return 1
+		}
+		return core + level + 10
+	}
+
+	return (core + 5) * nature.percent(stat) / 100
+}
+
+// Range returns the lowest and highest value a stat can have at a level: no
+// IVs or EVs with a hindering nature, up to perfect IVs, full EVs and a
+// helpful nature.
+func Range(stat string, base, level int) (low, high int) {
+	hindering := Nature{Increased: "", Decreased: stat}
+	helpful := Nature{Increased: stat, Decreased: ""}
+
+	low = Calc(stat, base, 0, 0, level, hindering)
+	high = Calc(stat, base, MaxIV, MaxEV, level, helpful)
+	return low, high
+}
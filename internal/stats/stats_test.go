@@ -0,0 +1,69 @@
+package stats
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCalc(t *testing.T) {
+	adamant := Nature{Name: "adamant", Increased: Attack, Decreased: SpecialAttack}
+	hardy := Nature{Name: "hardy", Increased: Attack, Decreased: Attack}
+
+	// Garchomp at level 78 from the games' own worked example.
+	cases := []struct {
+		stat     string
+		base     int
+		iv       int
+		ev       int
+		nature   Nature
+		expected int
+	}{
+		{stat: HP, base: 108, iv: 24, ev: 74, nature: adamant, expected: 289},
+		{stat: Attack, base: 130, iv: 12, ev: 190, nature: adamant, expected: 278},
+		{stat: Defense, base: 95, iv: 30, ev: 91, nature: adamant, expected: 193},
+		{stat: SpecialAttack, base: 80, iv: 16, ev: 48, nature: adamant, expected: 135},
+		{stat: SpecialDefense, base: 85, iv: 23, ev: 84, nature: adamant, expected: 171},
+		{stat: Speed, base: 102, iv: 5, ev: 23, nature: adamant, expected: 171},
+		{stat: SpecialAttack, base: 80, iv: 16, ev: 48, nature: hardy, expected: 151},
+		{stat: HP, base: 1, iv: 31, ev: 252, nature: hardy, expected: 1},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			value := Calc(c.stat, c.base, c.iv, c.ev, 78, c.nature)
+			if value != c.expected {
+				t.Errorf("expected %d, got %d", c.expected, value)
+				return
+			}
+		})
+	}
+}
+
+func TestRange(t *testing.T) {
+	cases := []struct {
+		stat string
+		base int
+		low  int
+		high int
+	}{
+		{stat: HP, base: 35, low: 180, high: 274},
+		{stat: Speed, base: 90, low: 166, high: 306},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			low, high := Range(c.stat, c.base, 100)
+			if low != c.low || high != c.high {
+				t.Errorf("expected %d-%d, got %d-%d", c.low, c.high, low, high)
+				return
+			}
+		})
+	}
+}
+
+func TestMultiplier(t *testing.T) {
+	modest := Nature{Name: "modest", Increased: SpecialAttack, Decreased: Attack}
+	if modest.Multiplier(SpecialAttack) != 1.1 || modest.Multiplier(Attack) != 0.9 || modest.Multiplier(Speed) != 1 {
+		t.Errorf("unexpected multipliers for %s", modest.Name)
+	}
+}
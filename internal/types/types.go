@@ -0,0 +1,166 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// All lists the 18 types in the order the games list them.
+var All = []string{
+	"normal", "fire", "water", "electric", "grass", "ice",
+	"fighting", "poison", "ground", "flying", "psychic", "bug",
+	"rock", "ghost", "dragon", "dark", "steel", "fairy",
+}
+
+type namedResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// Type is the part of the /type/{name} response the chart needs.
+type Type struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	DamageRelations struct {
+		DoubleDamageFrom []namedResource `json:"double_damage_from"`
+		DoubleDamageTo   []namedResource `json:"double_damage_to"`
+		HalfDamageFrom   []namedResource `json:"half_damage_from"`
+		HalfDamageTo     []namedResource `json:"half_damage_to"`
+		NoDamageFrom     []namedResource `json:"no_damage_from"`
+		NoDamageTo       []namedResource `json:"no_damage_to"`
+	} `json:"damage_relations"`
+}
+
+// Relations lists the types in each damage relation of a type.
+type Relations struct {
+	DoubleDamageTo   []string `json:"double_damage_to"`
+	HalfDamageTo     []string `json:"half_damage_to"`
+	NoDamageTo       []string `json:"no_damage_to"`
+	DoubleDamageFrom []string `json:"double_damage_from"`
+	HalfDamageFrom   []string `json:"half_damage_from"`
+	NoDamageFrom     []string `json:"no_damage_from"`
+}
+
+func (t Type) Relations() Relations {
+	return Relations{
+		DoubleDamageTo:   names(t.DamageRelations.DoubleDamageTo),
+		HalfDamageTo:     names(t.DamageRelations.HalfDamageTo),
+		NoDamageTo:       names(t.DamageRelations.NoDamageTo),
+		DoubleDamageFrom: names(t.DamageRelations.DoubleDamageFrom),
+		HalfDamageFrom:   names(t.DamageRelations.HalfDamageFrom),
+		NoDamageFrom:     names(t.DamageRelations.NoDamageFrom),
+	}
+}
+
+func names(resources []namedResource) []string {
+	list := []string{}
+	for _, r := range resources {
+		list = append(list, r.Name)
+	}
+	return list
+}
+
+// Chart answers type effectiveness questions, loading each type's damage
+// relations once through the fetch function it was created with.
+type Chart struct {
+	fetch func(name string, v any) error
+	types map[string]Type
+	mu    *sync.Mutex
+}
+
+// NewChart creates a chart that decodes /type/{name} responses with fetch.
+func NewChart(fetch func(name string, v any) error) *Chart {
+	return &Chart{
+		fetch: fetch,
+		types: map[string]Type{},
+		mu:    &sync.Mutex{},
+	}
+}
+
+// IsType reports whether name is one of the 18 types.
+func IsType(name string) bool {
+	return slices.Contains(All, name)
+}
+
+// Type returns the damage relations of a type.
+func (c *Chart) Type(name string) (Type, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if t, ok := c.types[name]; ok {
+		return t, nil
+	}
+
+	if !IsType(name) {
+		return Type{}, fmt.Errorf("unknown type %q", name)
+	}
+
+	t := Type{}
+	err := c.fetch(name, &t)
+	if err != nil {
+		return Type{}, err
+	}
+
+	c.types[name] = t
+	return t, nil
+}
+
+// Effectiveness returns the damage multiplier of an attack of the given type
+// against a defender with one or two types, e.g. 4 for ice against a
+// dragon/flying Pokemon.
+func (c *Chart) Effectiveness(attack string, defenders ...string) (float64, error) {
+	if !IsType(attack) {
+		return 0, fmt.Errorf("unknown type %q", attack)
+	}
+
+	multiplier := 1.0
+
+	for _, defender := range defenders {
+		t, err := c.Type(defender)
+		if err != nil {
+			return 0, err
+		}
+		multiplier *= relationMultiplier(t, attack)
+	}
+
+	return multiplier, nil
+}
+
+func relationMultiplier(defender Type, attack string) float64 {
+	relations := defender.DamageRelations
+	switch {
+	case contains(relations.NoDamageFrom, attack):
+		return 0
+	case contains(relations.DoubleDamageFrom, attack):
+		return 2
+	case contains(relations.HalfDamageFrom, attack):
+		return 0.5
+	}
+	return 1
+}
+
+func contains(resources []namedResource, name string) bool {
+	for _, r := range resources {
+		if r.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Defense returns the multiplier every attacking type has against a defender
+// with the given types.
+func (c *Chart) Defense(defenders ...string) (map[string]float64, error) {
+	multipliers := map[string]float64{}
+
+	for _, attack := range All {
+		multiplier, err := c.Effectiveness(attack, defenders...)
+		if err != nil {
+			return nil, err
+		}
+		multipliers[attack] = multiplier
+	}
+
+	return multipliers, nil
+}
@@ -0,0 +1,126 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func testType(name string, doubleFrom, halfFrom, noFrom []string) Type {
+	t := Type{Name: name}
+	for _, n := range doubleFrom {
+		t.DamageRelations.DoubleDamageFrom = append(t.DamageRelations.DoubleDamageFrom, namedResource{Name: n})
+	}
+	for _, n := range halfFrom {
+		t.DamageRelations.HalfDamageFrom = append(t.DamageRelations.HalfDamageFrom, namedResource{Name: n})
+	}
+	for _, n := range noFrom {
+		t.DamageRelations.NoDamageFrom = append(t.DamageRelations.NoDamageFrom, namedResource{Name: n})
+	}
+	return t
+}
+
+var testTypes = map[string]Type{
+	"water":  testType("water", []string{"electric", "grass"}, []string{"fire", "water", "ice", "steel"}, nil),
+	"ground": testType("ground", []string{"water", "grass", "ice"}, []string{"poison", "rock"}, []string{"electric"}),
+	"flying": testType("flying", []string{"electric", "ice", "rock"}, []string{"grass", "fighting", "bug"}, []string{"ground"}),
+	"dragon": testType("dragon", []string{"ice", "dragon", "fairy"}, []string{"fire", "water", "electric", "grass"}, nil),
+}
+
+func newTestChart(fetches *int) *Chart {
+	return NewChart(func(name string, v any) error {
+		*fetches++
+		t, ok := testTypes[name]
+		if !ok {
+			return errors.New("not found")
+		}
+		*v.(*Type) = t
+		return nil
+	})
+}
+
+func TestEffectiveness(t *testing.T) {
+	cases := []struct {
+		attack    string
+		defenders []string
+		expected  float64
+	}{
+		{
+			attack:    "electric",
+			defenders: []string{"water"},
+			expected:  2,
+		},
+		{
+			attack:    "normal",
+			defenders: []string{"water"},
+			expected:  1,
+		},
+		{
+			attack:    "electric",
+			defenders: []string{"water", "ground"},
+			expected:  0,
+		},
+		{
+			attack:    "ice",
+			defenders: []string{"dragon", "flying"},
+			expected:  4,
+		},
+		{
+			attack:    "water",
+			defenders: []string{"water", "dragon"},
+			expected:  0.25,
+		},
+		{
+			attack:    "grass",
+			defenders: []string{"water", "flying"},
+			expected:  1,
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			fetches := 0
+			chart := newTestChart(&fetches)
+			multiplier, err := chart.Effectiveness(c.attack, c.defenders...)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if multiplier != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, multiplier)
+				return
+			}
+		})
+	}
+}
+
+func TestTypesAreCached(t *testing.T) {
+	fetches := 0
+	chart := newTestChart(&fetches)
+
+	_, err := chart.Defense("water", "ground")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	if fetches != 2 {
+		t.Errorf("expected 2 fetches, got %d", fetches)
+		return
+	}
+}
+
+func TestUnknownType(t *testing.T) {
+	fetches := 0
+	chart := newTestChart(&fetches)
+
+	_, err := chart.Effectiveness("electric", "cosmic")
+	if err == nil {
+		t.Errorf("expected an error")
+		return
+	}
+	if fetches != 0 {
+		t.Errorf("expected no fetches for an unknown type, got %d", fetches)
+		return
+	}
+}
@@ -0,0 +1,430 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"pokedex/internal/battle"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Item kinds decide what "use" does with an item.
+const (
+	itemBall      = "ball"
+	itemMedicine  = "medicine"
+	itemEvolution = "evolution"
+	itemBerry     = "berry"
+	itemOther     = "other"
+)
+
+// itemKinds maps the API's item categories to item kinds. Berries are spread
+// over many categories, so they are recognised by name instead.
+var itemKinds = map[string]string{
+	"standard-balls": itemBall,
+	"special-balls":  itemBall,
+	"apricorn-balls": itemBall,
+	"healing":        itemMedicine,
+	"status-cures":   itemMedicine,
+	"revival":        itemMedicine,
+	"pp-recovery":    itemMedicine,
+	"vitamins":       itemMedicine,
+	"evolution":      itemEvolution,
+}
+
+// startingBag is what a new trainer sets out with.
+var startingBag = map[string]int{
+	"poke-ball": 10,
+	"potion":    2,
+}
+
+// exploreFinds are the items that can turn up while exploring an area, one
+// in every exploreFindChance explores.
+var exploreFinds = []string{
+	"poke-ball", "poke-ball", "great-ball", "ultra-ball",
+	"potion", "super-potion", "antidote", "oran-berry", "pecha-berry",
+	"fire-stone", "water-stone", "thunder-stone", "leaf-stone", "moon-stone",
+}
+
+const exploreFindChance = 4
+
+// healing is what a medicine or berry does: restore HP, a fixed amount, a
+// Part of the maximum or all of it when Full, cure status conditions, or
+// revive a fainted Pokemon with half its HP, or all of it when Full.
+type healing struct {
+	HP     int
+	Part   int
+	Full   bool
+	Cures  []string
+	Revive bool
+}
+
+// healingCategories are the item categories of medicine that heals. Berries
+// heal too, whatever their category.
+var healingCategories = []string{"healing", "status-cures", "revival"}
+
+var allStatuses = []string{battle.Paralysis, battle.Sleep, battle.Poison, battle.Burn, battle.Freeze}
+
+// statusWords are how item effects name the status conditions they cure.
+var statusWords = map[string]string{
+	"paraly": battle.Paralysis,
+	"sleep":  battle.Sleep,
+	"poison": battle.Poison,
+	"burn":   battle.Burn,
+	"freez":  battle.Freeze,
+	"frozen": battle.Freeze,
+}
+
+var (
+	restoresHP   = regexp.MustCompile(`restores (\d+) hp`)
+	restoresPart = regexp.MustCompile(`restores 1/(\d+)`)
+	curesAll     = regexp.MustCompile(`(any|all)( major)? status`)
+)
+
+// itemHealing reads what a medicine or berry does from its category and its
+// short effect, such as "Restores 20 HP." or "Cures poison.". Items that do
+// nothing the Pokedex keeps track of, like vitamins or ethers, don't heal.
+func itemHealing(item Item) (healing, bool) {
+	if !slices.Contains(healingCategories, item.Category.Name) && itemKind(item) != itemBerry {
+		return healing{}, false
+	}
+
+	effect := strings.ToLower(itemEffect(item))
+	heal := healing{Revive: item.Category.Name == "revival", Cures: []string{}}
+
+	if match := restoresHP.FindStringSubmatch(effect); match != nil {
+		heal.HP, _ = strconv.Atoi(match[1])
+	}
+	if match := restoresPart.FindStringSubmatch(effect); match != nil {
+		heal.Part, _ = strconv.Atoi(match[1])
+	}
+	heal.Full = strings.Contains(effect, "hp fully") || strings.Contains(effect, "full hp")
+
+	if curesAll.MatchString(effect) {
+		heal.Cures = allStatuses
+	} else {
+		for word, status := range statusWords {
+			if strings.Contains(effect, word) && !slices.Contains(heal.Cures, status) {
+				heal.Cures = append(heal.Cures, status)
+			}
+		}
+	}
+
+	ok := heal.Revive || heal.HP > 0 || heal.Part > 0 || heal.Full || len(heal.Cures) > 0
+	return heal, ok
+}
+
+type bagItem struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type bagResult struct {
+	Items []bagItem `json:"items"`
+}
+
+func (r bagResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Your bag:")
+
+	if len(r.Items) == 0 {
+		fmt.Fprintln(w, "   empty")
+	}
+
+	for _, item := range r.Items {
+		fmt.Fprintf(w, "   %3dx %s\n", item.Count, item.Name)
+	}
+
+	fmt.Fprintln(w)
+}
+
+func (r bagResult) tableRows() ([]string, [][]string) {
+	rows := [][]string{}
+	for _, item := range r.Items {
+		rows = append(rows, []string{item.Name, strconv.Itoa(item.Count)})
+	}
+	return []string{"ITEM", "COUNT"}, rows
+}
+
+type itemInfo struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Kind     string `json:"kind"`
+	Cost     int    `json:"cost"`
+	Effect   string `json:"effect"`
+	InBag    int    `json:"in_bag"`
+}
+
+func (r itemInfo) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Name: %s\n", r.Name)
+	fmt.Fprintf(w, "Category: %s\n", r.Category)
+	fmt.Fprintf(w, "Cost: %d\n", r.Cost)
+	if r.Effect != "" {
+		fmt.Fprintf(w, "Effect: %s\n", r.Effect)
+	}
+	fmt.Fprintf(w, "In bag: %d\n", r.InBag)
+	fmt.Fprintln(w)
+}
+
+func (r itemInfo) tableRows() ([]string, [][]string) {
+	return []string{"FIELD", "VALUE"}, [][]string{
+		{"name", r.Name},
+		{"category", r.Category},
+		{"kind", r.Kind},
+		{"cost", strconv.Itoa(r.Cost)},
+		{"effect", r.Effect},
+		{"in_bag", strconv.Itoa(r.InBag)},
+	}
+}
+
+type useResult struct {
+	Item      string `json:"item"`
+	Pokemon   string `json:"pokemon"`
+	Effect    string `json:"effect"`
+	EvolvedTo string `json:"evolved_to,omitempty"`
+	// Battle is the wild Pokemon's turn when the item was used in a battle.
+	Battle *battleResult `json:"battle,omitempty"`
+}
+
+func (r useResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "You used %s on %s.\n", r.Item, r.Pokemon)
+	if r.EvolvedTo != "" {
+		fmt.Fprintf(w, "%s evolved into %s!\n", r.Pokemon, r.EvolvedTo)
+	} else if r.Effect != "" {
+		fmt.Fprintln(w, r.Effect)
+	}
+	if r.Battle != nil {
+		r.Battle.renderTurn(w)
+	}
+	fmt.Fprintln(w)
+}
+
+func (config *Config) getItem(name string) (Item, error) {
+	item := Item{}
+	err := config.fetch(config.Item+name, &item)
+	if errors.Is(err, errNotFound) {
+		return item, errors.New("invalid item name.")
+	}
+	return item, err
+}
+
+func itemKind(item Item) string {
+	if strings.HasSuffix(item.Name, "-berry") {
+		return itemBerry
+	}
+	if kind, ok := itemKinds[item.Category.Name]; ok {
+		return kind
+	}
+	return itemOther
+}
+
+// itemEffect returns the short English effect of an item.
+func itemEffect(item Item) string {
+	for _, entry := range item.EffectEntries {
+		if entry.Language.Name == language {
+			return strings.Join(strings.Fields(entry.ShortEffect), " ")
+		}
+	}
+	return ""
+}
+
+func commandBag(config *Config, args cmdArgs) (any, error) {
+	result := bagResult{Items: []bagItem{}}
+
+	for name, count := range config.Bag {
+		result.Items = append(result.Items, bagItem{Name: name, Count: count})
+	}
+
+	sort.Slice(result.Items, func(i, j int) bool {
+		return result.Items[i].Name < result.Items[j].Name
+	})
+
+	return result, nil
+}
+
+func commandItem(config *Config, args cmdArgs) (any, error) {
+	item, err := config.getItem(args.arg(0))
+	if err != nil {
+		return nil, err
+	}
+
+	return itemInfo{
+		Name:     item.Name,
+		Category: item.Category.Name,
+		Kind:     itemKind(item),
+		Cost:     item.Cost,
+		Effect:   itemEffect(item),
+		InBag:    config.Bag[item.Name],
+	}, nil
+}
+
+func commandUse(config *Config, args cmdArgs) (any, error) {
+	name := args.arg(0)
+	if config.Bag[name] == 0 {
+		return nil, fmt.Errorf("you don't have any %s.", name)
+	}
+
+	item, err := config.getItem(name)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := itemKind(item)
+	if kind == itemBall {
+		return config.catchWild(args.arg(1), item.Name, methodWalk)
+	}
+
+	target := args.arg(1)
+	if target == "" {
+		return nil, usageError{commands["use"], fmt.Sprintf("%s has to be used on a pokemon", item.Name)}
+	}
+
+	var result useResult
+	switch kind {
+	case itemMedicine, itemBerry:
+		// Medicine the Pokedex does not model, such as vitamins and
+		// ethers, would be used up without doing anything.
+		heal, ok := itemHealing(item)
+		if !ok {
+			return nil, fmt.Errorf("%s can't be used here.", item.Name)
+		}
+		caught, err := config.findOwned(target)
+		if err != nil {
+			return nil, err
+		}
+		effect, err := config.heal(caught, item.Name, heal)
+		if err != nil {
+			return nil, err
+		}
+		result = useResult{Item: item.Name, Pokemon: caught.Name(), Effect: effect}
+	case itemEvolution:
+		if config.Battle != nil {
+			return nil, errInBattle
+		}
+		caught, err := config.findOwned(target)
+		if err != nil {
+			return nil, err
+		}
+		name := caught.Name()
+		err = config.evolveWithItem(caught, item.Name)
+		if err != nil {
+			return nil, err
+		}
+		result = useResult{Item: item.Name, Pokemon: name, EvolvedTo: caught.Pokemon.Name}
+	default:
+		return nil, fmt.Errorf("%s can't be used here.", item.Name)
+	}
+
+	// Using an item in a battle takes the player's turn, unless the
+	// Pokemon that is out has fainted and has to be switched anyway. The
+	// item is only used up once the turn is over.
+	if config.Battle != nil {
+		config.Battle.reload()
+		if !config.Battle.engine.Over() {
+			turn, err := config.wildTurn()
+			if err != nil {
+				return nil, err
+			}
+			result.Battle = &turn
+		}
+	}
+
+	config.takeItem(item.Name)
+
+	return result, nil
+}
+
+// heal applies a medicine or berry to one of the player's Pokemon, returning
+// what it did.
+func (config *Config) heal(caught *CaughtPokemon, item string, heal healing) (string, error) {
+	noEffect := fmt.Errorf("the %s had no effect on %s.", item, caught.Name())
+
+	if caught.Fainted() != heal.Revive {
+		return "", noEffect
+	}
+
+	maxHP, err := config.maxHP(caught)
+	if err != nil {
+		return "", err
+	}
+
+	if heal.Revive {
+		caught.HP = max(1, maxHP/2)
+		if heal.Full {
+			caught.HP = maxHP
+		}
+		caught.Status = ""
+		return fmt.Sprintf("%s was revived with %d HP.", caught.Name(), caught.HP), nil
+	}
+
+	effects := []string{}
+	if heal.HP > 0 || heal.Part > 0 || heal.Full {
+		restored := maxHP - caught.HP
+		switch {
+		case heal.Full:
+		case heal.Part > 0:
+			restored = min(restored, max(1, maxHP/heal.Part))
+		default:
+			restored = min(restored, heal.HP)
+		}
+		if restored > 0 {
+			caught.HP += restored
+			effects = append(effects, fmt.Sprintf("%s recovered %d HP.", caught.Name(), restored))
+		}
+	}
+	if caught.Status != "" && slices.Contains(heal.Cures, caught.Status) {
+		effects = append(effects, fmt.Sprintf("%s was cured of its %s.", caught.Name(), caught.Status))
+		caught.Status = ""
+	}
+
+	if len(effects) == 0 {
+		return "", noEffect
+	}
+	return strings.Join(effects, " "), nil
+}
+
+// evolveWithItem evolves a caught Pokemon whose species evolves when the item
+// is used on it.
+func (config *Config) evolveWithItem(caught *CaughtPokemon, item string) error {
+	into, err := config.evolutionFor(caught, triggerUseItem, item)
+	if err != nil {
+		return err
+	}
+	if into == "" {
+		return fmt.Errorf("the %s had no effect on %s.", item, caught.Name())
+	}
+
+	return config.evolve(caught, into)
+}
+
+func (config *Config) takeItem(name string) {
+	config.Bag[name]--
+	if config.Bag[name] <= 0 {
+		delete(config.Bag, name)
+	}
+}
+
+// findItem occasionally puts a random item in the bag, returning its name or
+// "" when nothing was found.
+func (config *Config) findItem() string {
+	if config.Rand.Intn(exploreFindChance) != 0 {
+		return ""
+	}
+
+	name := exploreFinds[config.Rand.Intn(len(exploreFinds))]
+	config.Bag[name]++
+	return name
+}
+
+func completeBag(config *Config, before []string) []string {
+	names := []string{}
+	for name := range config.Bag {
+		names = append(names, name)
+	}
+	return names
+}
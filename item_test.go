@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"pokedex/internal/battle"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// testItem builds an item of a category with an English short effect.
+func testItem(t *testing.T, name string, category string, effect string) Item {
+	item := Item{}
+	err := json.Unmarshal([]byte(fmt.Sprintf(
+		`{"name": %q, "category": {"name": %q}, "effect_entries": [{"short_effect": %q, "language": {"name": "en"}}]}`,
+		name, category, effect)), &item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return item
+}
+
+func TestItemHealing(t *testing.T) {
+	cases := []struct {
+		input    Item
+		expected healing
+		ok       bool
+	}{
+		{
+			input:    testItem(t, "potion", "healing", "Restores 20 HP."),
+			expected: healing{HP: 20, Cures: []string{}},
+			ok:       true,
+		},
+		{
+			input:    testItem(t, "max-potion", "healing", "Restores a Pokémon's HP fully."),
+			expected: healing{Full: true, Cures: []string{}},
+			ok:       true,
+		},
+		{
+			input:    testItem(t, "full-restore", "healing", "Restores HP fully and cures any major status ailment."),
+			expected: healing{Full: true, Cures: allStatuses},
+			ok:       true,
+		},
+		{
+			input:    testItem(t, "antidote", "status-cures", "Cures poison."),
+			expected: healing{Cures: []string{battle.Poison}},
+			ok:       true,
+		},
+		{
+			input:    testItem(t, "full-heal", "status-cures", "Cures any major status ailment."),
+			expected: healing{Cures: allStatuses},
+			ok:       true,
+		},
+		{
+			input:    testItem(t, "revive", "revival", "Revives a fainted Pokémon to half its max HP."),
+			expected: healing{Revive: true, Cures: []string{}},
+			ok:       true,
+		},
+		{
+			input:    testItem(t, "max-revive", "revival", "Revives a fainted Pokémon to full HP."),
+			expected: healing{Revive: true, Full: true, Cures: []string{}},
+			ok:       true,
+		},
+		{
+			input:    testItem(t, "oran-berry", "medicine", "Held: Restores 10 HP when at 50% HP or less."),
+			expected: healing{HP: 10, Cures: []string{}},
+			ok:       true,
+		},
+		{
+			input:    testItem(t, "sitrus-berry", "medicine", "Held: Restores 1/4 max HP when at 50% HP or less."),
+			expected: healing{Part: 4, Cures: []string{}},
+			ok:       true,
+		},
+		{
+			input:    testItem(t, "cheri-berry", "medicine", "Held: Consumed when paralyzed to cure paralysis."),
+			expected: healing{Cures: []string{battle.Paralysis}},
+			ok:       true,
+		},
+		{
+			input: testItem(t, "calcium", "vitamins", "Raises Special Attack effort by 10."),
+			ok:    false,
+		},
+		{
+			input: testItem(t, "ether", "pp-recovery", "Restores 10 PP."),
+			ok:    false,
+		},
+		{
+			input: testItem(t, "liechi-berry", "in-a-pinch", "Held: Raises Attack by one stage when at 25% HP or less."),
+			ok:    false,
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			actual, ok := itemHealing(c.input)
+			if ok != c.ok {
+				t.Fatalf("Expected ok %v for %s, got %v", c.ok, c.input.Name, ok)
+			}
+			if !ok {
+				return
+			}
+			// Cures are compared in any order.
+			actual.Cures = slices.Sorted(slices.Values(actual.Cures))
+			c.expected.Cures = slices.Sorted(slices.Values(c.expected.Cures))
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("Expected %+v for %s, got %+v", c.expected, c.input.Name, actual)
+			}
+		})
+	}
+}
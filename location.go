@@ -0,0 +1,236 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// anyVersion is the game version setting that allows encounters from every
+// game.
+const anyVersion = "any"
+
+// methodWalk is the encounter method used when none is given: walking in
+// tall grass.
+const methodWalk = "walk"
+
+// wildEncounter is one way a Pokemon can be met in an area in the selected
+// game version.
+type wildEncounter struct {
+	Pokemon  string
+	Version  string
+	Method   string
+	Chance   int
+	MinLevel int
+	MaxLevel int
+	// Conditions are the condition values the encounter needs, such as
+	// "time-night" or "season-winter".
+	Conditions []string
+}
+
+func (config *Config) getVersion(name string) error {
+	version := struct {
+		Name string `json:"name"`
+	}{}
+	err := config.fetch(config.Version+name, &version)
+	if errors.Is(err, errNotFound) {
+		return fmt.Errorf("unknown game version %q", name)
+	}
+	return err
+}
+
+func commandTravel(config *Config, args cmdArgs) (any, error) {
+	if config.Battle != nil {
+		return nil, errInBattle
+	}
+
+	area, err := config.getArea(args.arg(0))
+	if err != nil {
+		return nil, err
+	}
+
+	config.Area = area.Name
+	config.Wild = nil
+	return message{Message: fmt.Sprintf("You traveled to %s.", area.Name)}, nil
+}
+
+// currentArea returns the area the player explored or traveled to last.
+func (config *Config) currentArea() (Explore, error) {
+	if config.Area == "" {
+		return Explore{}, errors.New("you are not in an area yet, explore or travel to one first.")
+	}
+	return config.getArea(config.Area)
+}
+
+// areaEncounters lists the encounters of an area in the selected game
+// version.
+func (config *Config) areaEncounters(area Explore) []wildEncounter {
+	encounters := []wildEncounter{}
+
+	for _, encounter := range area.PokemonEncounters {
+		for _, version := range encounter.VersionDetails {
+			if config.GameVersion != anyVersion && version.Version.Name != config.GameVersion {
+				continue
+			}
+			for _, detail := range version.EncounterDetails {
+				conditions := []string{}
+				for _, condition := range detail.ConditionValues {
+					conditions = append(conditions, condition.Name)
+				}
+				encounters = append(encounters, wildEncounter{
+					Pokemon:    encounter.Pokemon.Name,
+					Version:    version.Version.Name,
+					Method:     detail.Method.Name,
+					Chance:     detail.Chance,
+					MinLevel:   detail.MinLevel,
+					MaxLevel:   detail.MaxLevel,
+					Conditions: conditions,
+				})
+			}
+		}
+	}
+
+	return encounters
+}
+
+// encountersOf picks the encounters of one Pokemon.
+func encountersOf(encounters []wildEncounter, pokemon string) []wildEncounter {
+	found := []wildEncounter{}
+	for _, encounter := range encounters {
+		if encounter.Pokemon == pokemon {
+			found = append(found, encounter)
+		}
+	}
+	return found
+}
+
+// inVersion keeps the encounters of one game version and method.
+func inVersion(encounters []wildEncounter, version string, method string) []wildEncounter {
+	found := []wildEncounter{}
+	for _, encounter := range encounters {
+		if encounter.Version == version && encounter.Method == method {
+			found = append(found, encounter)
+		}
+	}
+	return found
+}
+
+// pickVersion picks the game version to look for a Pokemon in: the selected
+// one, or one of the versions it can be met in when any version is allowed.
+func (config *Config) pickVersion(encounters []wildEncounter) string {
+	if config.GameVersion != anyVersion {
+		return config.GameVersion
+	}
+
+	versions := []string{}
+	for _, encounter := range encounters {
+		if !slices.Contains(versions, encounter.Version) {
+			versions = append(versions, encounter.Version)
+		}
+	}
+	if len(versions) == 0 {
+		return ""
+	}
+	return versions[config.Rand.Intn(len(versions))]
+}
+
+// rollEncounter decides whether a Pokemon shows up, with a percent chance of
+// the encounter chances added up, and picks the encounter it shows up
+// through. The encounters have to be from one version and method, where the
+// chances of all Pokemon add up to 100.
+func rollEncounter(rng *rand.Rand, encounters []wildEncounter) (wildEncounter, bool) {
+	total := 0
+	for _, encounter := range encounters {
+		total += encounter.Chance
+	}
+
+	if rng.Intn(100) >= total {
+		return wildEncounter{}, false
+	}
+
+	return pickEncounter(rng, encounters), true
+}
+
+func (e wildEncounter) rollLevel(rng *rand.Rand) int {
+	if e.MaxLevel <= e.MinLevel {
+		return e.MinLevel
+	}
+	return e.MinLevel + rng.Intn(e.MaxLevel-e.MinLevel+1)
+}
+
+// catchWild throws a ball at the Pokemon met with encounter. Without one it
+// looks for the named Pokemon in the current area and throws a ball at it
+// when it shows up.
+func (config *Config) catchWild(name string, ball string, method string) (any, error) {
+	if config.Wild != nil {
+		return config.catchEncountered(name, ball)
+	}
+	if name == "" {
+		return nil, errors.New("there is no wild pokemon here, name the one to look for.")
+	}
+
+	area, err := config.currentArea()
+	if err != nil {
+		return nil, err
+	}
+
+	encounters := encountersOf(possibleEncounters(config.areaEncounters(area), config.Clock()), name)
+	if len(encounters) == 0 {
+		return nil, fmt.Errorf("%s can't be found in %s.", name, area.Name)
+	}
+	encounters = slices.DeleteFunc(encounters, func(e wildEncounter) bool { return e.Method != method })
+	if len(encounters) == 0 {
+		return nil, fmt.Errorf("%s can't be found in %s with %s.", name, area.Name, method)
+	}
+
+	// The ball, the Pokemon and its species are checked before the Pokemon
+	// is looked for, so a catch that fails on them does not draw from the
+	// seed. Only looking up the nature it is rolled with can still fail
+	// after that.
+	_, err = config.ballItem(ball)
+	if err != nil {
+		return nil, err
+	}
+
+	pokemon, err := config.getPokemon(name)
+	if err != nil {
+		return nil, err
+	}
+	species, err := config.getSpeciesOf(pokemon)
+	if err != nil {
+		return nil, err
+	}
+
+	// The odds of meeting the Pokemon are those of one game, as the
+	// chances of every game add up to 100 on their own.
+	encounters = inVersion(encounters, config.pickVersion(encounters), method)
+	encounter, ok := rollEncounter(config.Rand, encounters)
+	if !ok {
+		return message{Message: fmt.Sprintf("You searched %s, but no %s showed up.", area.Name, name)}, nil
+	}
+
+	wild, err := config.rollWild(pokemon, species, encounter.Method, encounter.rollLevel(config.Rand))
+	if err != nil {
+		return nil, err
+	}
+
+	caught, err := config.throwBall(wild, ball)
+	if err != nil {
+		return nil, err
+	}
+
+	return caught, nil
+}
+
+// rollWild rolls the traits of a Pokemon that shows up with an encounter
+// method, and marks it as seen once that worked.
+func (config *Config) rollWild(pokemon Pokemon, species PokemonSpecies, method string, level int) (*CaughtPokemon, error) {
+	wild, err := config.rollInstance(pokemon, species, level)
+	if err != nil {
+		return nil, err
+	}
+
+	config.markSeen(pokemon, method)
+	return wild, nil
+}
@@ -1,17 +1,31 @@
 package main
 
 import (
-	"bufio"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"maps"
+	"math/rand"
 	"os"
+	"path/filepath"
+	"pokedex/internal/lineedit"
 	"pokedex/internal/pokecache"
+	"pokedex/internal/types"
+	"slices"
+	"strings"
 	"time"
 )
 
 type cliCommand struct {
 	name        string
 	description string
-	callback    func(*Config, string) error
+	category    string
+	help        string
+	examples    []string
+	args        []argSpec
+	flags       []flagSpec
+	callback    func(*Config, cmdArgs) (any, error)
 }
 
 type Config struct {
@@ -19,8 +33,61 @@ type Config struct {
 	Previous string
 	Explore  string
 	Pokemon  string
-	Pokedex  map[string]Pokemon
-	Cache    pokecache.Cache
+	Species  string
+	Move     string
+	Ability  string
+	Type     string
+	Item     string
+	Version  string
+	Nature   string
+	// Dex is the endpoint of the official Pokedexes, while Pokedex holds
+	// the player's own.
+	Dex     string
+	Pokedex map[string]*DexEntry
+	Owned   map[int]*CaughtPokemon
+	NextID  int
+	// Party and Boxes hold the IDs of the player's Pokemon. Empty box
+	// slots are 0.
+	Party  []int
+	Boxes  [][]int
+	Bag    map[string]int
+	Cache  pokecache.Cache
+	Output string
+	// Rand is the source of every random outcome, from encounters to
+	// catches and battles. Seed is what it was last seeded with, so a
+	// session can be replayed with --seed.
+	Rand *rand.Rand
+	Seed int64
+	// Clock tells the time of day and season encounters and evolutions
+	// depend on. --time stops it at a fixed time, so replays see the same
+	// world as the session they replay.
+	Clock func() time.Time
+
+	// Area is the location area the player is in, set by explore and
+	// travel. Only Pokemon found there can be caught.
+	Area string
+	// Wild is the Pokemon met with encounter, or nil.
+	Wild *CaughtPokemon
+	// Battle is the fight with Wild the player is in, or nil.
+	Battle *battleState
+	// GameVersion selects the game encounters come from, or anyVersion.
+	GameVersion string
+
+	// TypeChart holds the damage relations of the types looked up so far.
+	TypeChart *types.Chart
+
+	// LastAreas and LastExplored remember the most recent map page and
+	// explore results for tab completion.
+	LastAreas    []string
+	LastExplored []string
+
+	// Aliases maps user defined names to the command line they expand to.
+	// Several commands can be separated with ";".
+	Aliases map[string]string
+
+	Echo            bool
+	ContinueOnError bool
+	scriptDepth     int
 }
 
 type mapResult struct {
@@ -37,34 +104,310 @@ type Location struct {
 
 var commands map[string]cliCommand
 
+// errExit is returned by a command to ask the REPL to shut down.
+var errExit = errors.New("exit requested")
+
+var errNoCommand = errors.New("no such command")
+
+// historySize is how many REPL lines are kept across sessions.
+const historySize = 500
+
+// Exit statuses for one-shot commands.
+const (
+	exitOK     = 0
+	exitFailed = 1
+	exitUsage  = 2
+)
+
 func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: pokedex [flags] [COMMAND [ARGS...]]")
+		fmt.Fprintln(flag.CommandLine.Output(), "       pokedex [flags] run FILE")
+		fmt.Fprintln(flag.CommandLine.Output(), "\nWithout a command the interactive Pokedex is started, or commands are read")
+		fmt.Fprintln(flag.CommandLine.Output(), "from stdin when it is not a terminal.\n\nFlags:")
+		flag.PrintDefaults()
+	}
+	output := flag.String("output", outputPlain, "output format: "+strings.Join(outputFormats, ", "))
+	echo := flag.Bool("echo", false, "print each command before running it in scripts")
+	continueOnError := flag.Bool("continue", false, "keep running a script after a command fails")
+	seed := flag.Int64("seed", 0, "seed for random outcomes, to replay a session (default: based on the time)")
+	clock := flag.String("time", "", "fixed time of day and season, in RFC 3339 format, to replay a session (default: the current time)")
+
+	args, err := parseGlobalFlags(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		os.Exit(exitUsage)
+	}
+
+	if !validOutputFormat(*output) {
+		fmt.Fprintf(os.Stderr, "unknown output format %q\n", *output)
+		os.Exit(exitUsage)
+	}
+
 	config := Config{
 		Next:     "https://pokeapi.co/api/v2/location-area/",
 		Previous: "",
 		Explore:  "https://pokeapi.co/api/v2/location-area/",
 		Pokemon:  "https://pokeapi.co/api/v2/pokemon/",
-		Pokedex:  map[string]Pokemon{},
+		Species:  "https://pokeapi.co/api/v2/pokemon-species/",
+		Move:     "https://pokeapi.co/api/v2/move/",
+		Ability:  "https://pokeapi.co/api/v2/ability/",
+		Type:     "https://pokeapi.co/api/v2/type/",
+		Item:     "https://pokeapi.co/api/v2/item/",
+		Version:  "https://pokeapi.co/api/v2/version/",
+		Nature:   "https://pokeapi.co/api/v2/nature/",
+		Dex:      "https://pokeapi.co/api/v2/pokedex/",
+		Pokedex:  map[string]*DexEntry{},
+		Owned:    map[int]*CaughtPokemon{},
+		Bag:      maps.Clone(startingBag),
 		Cache:    *pokecache.NewCache(60 * time.Second),
+		Output:   *output,
+		Aliases:  map[string]string{},
+
+		GameVersion: anyVersion,
+		Clock:       time.Now,
+
+		Echo:            *echo,
+		ContinueOnError: *continueOnError,
+	}
+
+	if *clock != "" {
+		fixed, err := time.Parse(time.RFC3339, *clock)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid time %q, expected RFC 3339 like 2006-01-02T15:04:05Z\n", *clock)
+			os.Exit(exitUsage)
+		}
+		config.Clock = func() time.Time { return fixed }
+	}
+
+	// 0 is a seed like any other, so only a missing --seed picks one.
+	seeded := false
+	flag.Visit(func(f *flag.Flag) { seeded = seeded || f.Name == "seed" })
+	if !seeded {
+		*seed = time.Now().UnixNano()
 	}
+	config.reseed(*seed)
 
+	config.TypeChart = types.NewChart(config.fetchType)
 	commands = getCommands()
-	const prompt = "pokedex >"
-	scanner := bufio.NewScanner(os.Stdin)
-	fmt.Print(prompt)
 
-	for scanner.Scan() {
+	err = loadUserConfig(&config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "could not load config: %v\n", err)
+	}
+
+	if len(args) > 0 {
+		os.Exit(runOnce(&config, args))
+	}
+
+	if !isTerminal(os.Stdin) {
+		os.Exit(runPiped(&config, os.Stdin))
+	}
+
+	// Without --seed the session can only be replayed when its seed and
+	// start time are known.
+	if !seeded {
+		fmt.Fprintf(os.Stderr, "replay this session with --seed %d --time %s\n", *seed, config.Clock().Format(time.RFC3339))
+	}
+
+	editor := lineedit.New(os.Stdin, os.Stdout)
+	editor.Prompt = "pokedex >"
+	editor.Complete = config.complete
+	if path, err := configPath("history"); err == nil {
+		editor.History, _ = lineedit.LoadHistory(path, historySize)
+	}
+
+	for {
+		line, err := editor.ReadLine()
+		if errors.Is(err, lineedit.ErrInterrupted) {
+			continue
+		}
+		if err != nil {
+			return
+		}
+
+		err = runCommand(&config, line)
+		if errors.Is(err, errExit) {
+			os.Exit(0)
+		}
+	}
+}
+
+// reseed restarts the random source from a seed. The source is reseeded in
+// place, so a battle that holds on to it draws from the new seed too.
+func (config *Config) reseed(seed int64) {
+	config.Seed = seed
+	if config.Rand == nil {
+		config.Rand = rand.New(rand.NewSource(seed))
+		return
+	}
+	config.Rand.Seed(seed)
+}
+
+// runCommand parses and runs a single command line, rendering its result or
+// error in the configured output format.
+func runCommand(config *Config, line string) error {
+	tokens, err := tokenize(line)
+	if err != nil {
+		renderError(os.Stdout, config.Output, err)
+		return err
+	}
+
+	return runTokens(config, tokens)
+}
+
+// runTokens runs an already tokenized command line.
+func runTokens(config *Config, tokens []string) error {
+	return runExpanded(config, tokens, []string{})
+}
+
+// runExpanded runs tokens, expanding a user alias into the commands it stands
+// for. expanding holds the aliases already being expanded to catch cycles.
+func runExpanded(config *Config, tokens []string, expanding []string) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	// Built-in commands win over aliases of the same name.
+	name := strings.ToLower(tokens[0])
+	expansion, isAlias := config.Aliases[name]
+	if _, isCommand := commands[name]; isAlias && !isCommand {
+		if slices.Contains(expanding, name) || len(expanding) >= maxAliasDepth {
+			err := fmt.Errorf("alias %q expands into itself", name)
+			renderError(os.Stdout, config.Output, err)
+			return err
+		}
 
-		command := scanner.Text()
-		callback, args, err := parseCommand(command)
+		lines, err := expandAlias(expansion, tokens[1:])
 		if err != nil {
-			fmt.Println(`Invalid command. Type "help" for list of commands`)
-		} else {
-			err = callback(&config, args)
+			renderError(os.Stdout, config.Output, err)
+			return err
+		}
+
+		for _, line := range lines {
+			err = runExpanded(config, line, append(slices.Clip(expanding), name))
 			if err != nil {
-				fmt.Printf("Error: %v\n", err)
+				return err
 			}
 		}
+		return nil
+	}
+
+	cmd, args, err := parseCommand(tokens)
+	if errors.Is(err, errNoCommand) {
+		renderError(os.Stdout, config.Output, unknownCommandError(config, name))
+		return err
+	}
+	if err != nil {
+		renderError(os.Stdout, config.Output, err)
+		return err
+	}
+
+	result, err := cmd.callback(config, args)
+	if result != nil {
+		if renderErr := render(os.Stdout, config.Output, result); renderErr != nil {
+			return renderErr
+		}
+	}
+	// Structured formats emit one document per command, so a failing command
+	// that already produced a result does not get a second error document.
+	if err != nil && !errors.Is(err, errExit) && (result == nil || !isStructured(config.Output)) {
+		renderError(os.Stdout, config.Output, err)
+	}
+
+	return err
+}
+
+// runOnce runs a single command given on the command line and returns the
+// process exit status.
+func runOnce(config *Config, args []string) int {
+	err := runTokens(config, args)
+
+	switch {
+	case err == nil, errors.Is(err, errExit):
+		return exitOK
+	case errors.Is(err, errNoCommand), errors.As(err, &usageError{}):
+		return exitUsage
+	default:
+		return exitFailed
+	}
+}
+
+// runPiped runs commands read from a non-interactive stdin as a script and
+// returns the process exit status.
+func runPiped(config *Config, r io.Reader) int {
+	summary, err := runScript(config, r)
+	render(os.Stdout, config.Output, summary)
 
-		fmt.Print(prompt)
+	if err != nil && !errors.Is(err, errExit) {
+		renderError(os.Stdout, config.Output, err)
+		return exitFailed
 	}
+	if summary.Failed > 0 {
+		return exitFailed
+	}
+	return exitOK
+}
+
+// configPath returns the path of a file in the user's pokedex config
+// directory.
+func configPath(name string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "pokedex", name), nil
+}
+
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// parseGlobalFlags pulls the flags registered on fs out of args wherever they
+// appear, so "pokedex inspect pikachu --output json" works as well as putting
+// them first. Everything else is returned untouched for the command itself.
+func parseGlobalFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	globals := []string{}
+	rest := []string{}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		if arg == "--" {
+			rest = append(rest, args[i+1:]...)
+			break
+		}
+
+		name, hasValue := strings.CutPrefix(arg, "--")
+		if !hasValue {
+			name, hasValue = strings.CutPrefix(arg, "-")
+		}
+		name, _, inline := strings.Cut(name, "=")
+
+		if hasValue && (name == "h" || name == "help") {
+			globals = append(globals, arg)
+			continue
+		}
+
+		f := fs.Lookup(name)
+		if !hasValue || f == nil {
+			rest = append(rest, arg)
+			continue
+		}
+
+		globals = append(globals, arg)
+		if isBool, ok := f.Value.(interface{ IsBoolFlag() bool }); inline || (ok && isBool.IsBoolFlag()) {
+			continue
+		}
+		if i+1 < len(args) {
+			i++
+			globals = append(globals, args[i])
+		}
+	}
+
+	err := fs.Parse(globals)
+	return rest, err
 }
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// allMethods can be passed to "moves --method" to list every learn method.
+const allMethods = "all"
+
+type learnedMove struct {
+	Name   string `json:"name"`
+	Level  int    `json:"level"`
+	Method string `json:"method"`
+}
+
+type movesResult struct {
+	Pokemon      string        `json:"pokemon"`
+	VersionGroup string        `json:"version_group"`
+	Method       string        `json:"method"`
+	Moves        []learnedMove `json:"moves"`
+}
+
+func (r movesResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s's moves (%s, %s):\n", r.Pokemon, r.Method, r.VersionGroup)
+
+	if len(r.Moves) == 0 {
+		fmt.Fprintln(w, "   none")
+	}
+
+	for _, move := range r.Moves {
+		switch {
+		case move.Method == "level-up":
+			fmt.Fprintf(w, "   Lv %2d  %s\n", move.Level, move.Name)
+		case r.Method == allMethods:
+			fmt.Fprintf(w, "   %s (%s)\n", move.Name, move.Method)
+		default:
+			fmt.Fprintf(w, "   - %s\n", move.Name)
+		}
+	}
+
+	fmt.Fprintln(w)
+}
+
+func (r movesResult) tableRows() ([]string, [][]string) {
+	rows := [][]string{}
+	for _, move := range r.Moves {
+		rows = append(rows, []string{strconv.Itoa(move.Level), move.Name, move.Method})
+	}
+	return []string{"LEVEL", "MOVE", "METHOD"}, rows
+}
+
+type moveInfo struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	DamageClass  string `json:"damage_class"`
+	Power        *int   `json:"power"`
+	Accuracy     *int   `json:"accuracy"`
+	PP           int    `json:"pp"`
+	Priority     int    `json:"priority"`
+	Effect       string `json:"effect"`
+	EffectChance *int   `json:"effect_chance,omitempty"`
+}
+
+func (r moveInfo) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Name: %s\n", r.Name)
+	fmt.Fprintf(w, "Type: %s\n", r.Type)
+	fmt.Fprintf(w, "Category: %s\n", r.DamageClass)
+	fmt.Fprintf(w, "Power: %s\n", optionalInt(r.Power))
+	fmt.Fprintf(w, "Accuracy: %s\n", optionalInt(r.Accuracy))
+	fmt.Fprintf(w, "PP: %d\n", r.PP)
+	if r.Priority != 0 {
+		fmt.Fprintf(w, "Priority: %+d\n", r.Priority)
+	}
+	if r.Effect != "" {
+		fmt.Fprintf(w, "Effect: %s\n", r.Effect)
+	}
+	fmt.Fprintln(w)
+}
+
+func (r moveInfo) tableRows() ([]string, [][]string) {
+	return []string{"FIELD", "VALUE"}, [][]string{
+		{"name", r.Name},
+		{"type", r.Type},
+		{"damage_class", r.DamageClass},
+		{"power", optionalInt(r.Power)},
+		{"accuracy", optionalInt(r.Accuracy)},
+		{"pp", strconv.Itoa(r.PP)},
+		{"priority", strconv.Itoa(r.Priority)},
+		{"effect", r.Effect},
+	}
+}
+
+func optionalInt(n *int) string {
+	if n == nil {
+		return "-"
+	}
+	return strconv.Itoa(*n)
+}
+
+func (config *Config) getMove(name string) (Move, error) {
+	move := Move{}
+	err := config.fetch(config.Move+name, &move)
+	if errors.Is(err, errNotFound) {
+		return move, errors.New("invalid move name.")
+	}
+	return move, err
+}
+
+func commandMove(config *Config, args cmdArgs) (any, error) {
+	move, err := config.getMove(args.arg(0))
+	if err != nil {
+		return nil, err
+	}
+
+	return newMoveInfo(move), nil
+}
+
+func newMoveInfo(move Move) moveInfo {
+	return moveInfo{
+		Name:         move.Name,
+		Type:         move.Type.Name,
+		DamageClass:  move.DamageClass.Name,
+		Power:        move.Power,
+		Accuracy:     move.Accuracy,
+		PP:           move.Pp,
+		Priority:     move.Priority,
+		Effect:       moveEffect(move),
+		EffectChance: move.EffectChance,
+	}
+}
+
+// moveEffect returns the short English effect with the effect chance filled
+// in.
+func moveEffect(move Move) string {
+	for _, entry := range move.EffectEntries {
+		if entry.Language.Name != language {
+			continue
+		}
+		effect := entry.ShortEffect
+		if move.EffectChance != nil {
+			effect = strings.ReplaceAll(effect, "$effect_chance", strconv.Itoa(*move.EffectChance))
+		}
+		return strings.Join(strings.Fields(effect), " ")
+	}
+	return ""
+}
+
+func commandMoves(config *Config, args cmdArgs) (any, error) {
+	pokemon, err := config.getPokemon(args.arg(0))
+	if err != nil {
+		return nil, err
+	}
+
+	versionGroup := args.flag("version-group")
+	if versionGroup == "" {
+		versionGroup = latestVersionGroup(pokemon)
+	}
+
+	return newMovesResult(pokemon, versionGroup, args.flag("method")), nil
+}
+
+func newMovesResult(pokemon Pokemon, versionGroup string, method string) movesResult {
+	result := movesResult{
+		Pokemon:      pokemon.Name,
+		VersionGroup: versionGroup,
+		Method:       method,
+		Moves:        []learnedMove{},
+	}
+
+	for _, move := range pokemon.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.VersionGroup.Name != versionGroup {
+				continue
+			}
+			if method != allMethods && detail.MoveLearnMethod.Name != method {
+				continue
+			}
+			result.Moves = append(result.Moves, learnedMove{
+				Name:   move.Move.Name,
+				Level:  detail.LevelLearnedAt,
+				Method: detail.MoveLearnMethod.Name,
+			})
+		}
+	}
+
+	sort.SliceStable(result.Moves, func(i, j int) bool {
+		a, b := result.Moves[i], result.Moves[j]
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		if a.Level != b.Level {
+			return a.Level < b.Level
+		}
+		return a.Name < b.Name
+	})
+
+	return result
+}
+
+// versionGroups are the version groups of the main series games, oldest
+// first. The API's IDs do not follow release order, and side games, Japanese
+// releases and DLC are left out: they have few level-up moves, if any.
+var versionGroups = []string{
+	"red-blue", "yellow", "gold-silver", "crystal",
+	"ruby-sapphire", "emerald", "firered-leafgreen",
+	"diamond-pearl", "platinum", "heartgold-soulsilver",
+	"black-white", "black-2-white-2",
+	"x-y", "omega-ruby-alpha-sapphire",
+	"sun-moon", "ultra-sun-ultra-moon", "lets-go-pikachu-lets-go-eevee",
+	"sword-shield", "brilliant-diamond-and-shining-pearl", "legends-arceus",
+	"scarlet-violet",
+}
+
+// latestVersionGroup picks the newest main series game a Pokemon learns
+// moves in by leveling up. A Pokemon that only levels up in other games
+// gets the first of those the API lists.
+func latestVersionGroup(pokemon Pokemon) string {
+	latest, latestIndex := "", -1
+	for _, move := range pokemon.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.MoveLearnMethod.Name != "level-up" {
+				continue
+			}
+			name := detail.VersionGroup.Name
+			if i := slices.Index(versionGroups, name); i > latestIndex || latest == "" {
+				latest, latestIndex = name, max(i, latestIndex)
+			}
+		}
+	}
+	return latest
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// testMoves builds a Pokemon with one move for each list of version groups
+// it is learned in. A group is learned by leveling up unless it is given as
+// "group/method".
+func testMoves(t *testing.T, moves ...[]string) Pokemon {
+	details := []string{}
+	for i, groups := range moves {
+		entries := []string{}
+		for _, group := range groups {
+			name, method, ok := strings.Cut(group, "/")
+			if !ok {
+				method = "level-up"
+			}
+			entries = append(entries, fmt.Sprintf(
+				`{"level_learned_at": 1, "move_learn_method": {"name": %q}, "version_group": {"name": %q}}`,
+				method, name))
+		}
+		details = append(details, fmt.Sprintf(`{"move": {"name": "move-%d"}, "version_group_details": [%s]}`, i, strings.Join(entries, ", ")))
+	}
+
+	pokemon := Pokemon{}
+	err := json.Unmarshal([]byte(`{"name": "pikachu", "moves": [`+strings.Join(details, ", ")+`]}`), &pokemon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pokemon
+}
+
+func TestLatestVersionGroup(t *testing.T) {
+	cases := []struct {
+		input    Pokemon
+		expected string
+	}{
+		{
+			input:    testMoves(t, []string{"red-blue", "yellow", "sword-shield"}),
+			expected: "sword-shield",
+		},
+		{
+			// Listed newest first.
+			input:    testMoves(t, []string{"scarlet-violet", "sword-shield", "red-blue"}),
+			expected: "scarlet-violet",
+		},
+		{
+			// The newest group only shows up in a later move.
+			input:    testMoves(t, []string{"red-blue", "x-y"}, []string{"scarlet-violet"}, []string{"ultra-sun-ultra-moon"}),
+			expected: "scarlet-violet",
+		},
+		{
+			// Side games have higher IDs than black-white, but are not newer.
+			input:    testMoves(t, []string{"black-white", "colosseum", "xd"}),
+			expected: "black-white",
+		},
+		{
+			// DLC only teaches moves by tutor.
+			input:    testMoves(t, []string{"scarlet-violet", "the-teal-mask/tutor", "the-indigo-disk/tutor"}),
+			expected: "scarlet-violet",
+		},
+		{
+			// Groups without level-up moves are not picked.
+			input:    testMoves(t, []string{"sword-shield", "scarlet-violet/machine"}),
+			expected: "sword-shield",
+		},
+		{
+			// Only side games: the first one listed.
+			input:    testMoves(t, []string{"colosseum", "xd"}),
+			expected: "colosseum",
+		},
+		{
+			input:    testMoves(t),
+			expected: "",
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			actual := latestVersionGroup(c.input)
+			if actual != c.expected {
+				t.Errorf("Expected %q, got %q", c.expected, actual)
+			}
+		})
+	}
+}
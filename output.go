@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputPlain = "plain"
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+var outputFormats = []string{outputPlain, outputTable, outputJSON, outputYAML}
+
+// plainRenderer is implemented by command results that know how to print
+// themselves as human readable text.
+type plainRenderer interface {
+	renderPlain(w io.Writer)
+}
+
+// tableRenderer is implemented by command results that can be laid out as
+// rows under a header. Results without it fall back to plain text in table mode.
+type tableRenderer interface {
+	tableRows() ([]string, [][]string)
+}
+
+type message struct {
+	Message string `json:"message"`
+}
+
+func (m message) renderPlain(w io.Writer) {
+	fmt.Fprintln(w, m.Message)
+}
+
+type errorResult struct {
+	Error string `json:"error"`
+}
+
+func validOutputFormat(format string) bool {
+	for _, f := range outputFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// isStructured reports whether format is meant for other programs to parse.
+func isStructured(format string) bool {
+	return format == outputJSON || format == outputYAML
+}
+
+func render(w io.Writer, format string, result any) error {
+	switch format {
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	case outputYAML:
+		return renderYAML(w, result)
+	case outputTable:
+		if table, ok := result.(tableRenderer); ok {
+			renderTable(w, table)
+			return nil
+		}
+	}
+
+	if plain, ok := result.(plainRenderer); ok {
+		plain.renderPlain(w)
+		return nil
+	}
+
+	_, err := fmt.Fprintf(w, "%v\n", result)
+	return err
+}
+
+func renderError(w io.Writer, format string, err error) {
+	if isStructured(format) {
+		render(w, format, errorResult{Error: err.Error()})
+		return
+	}
+	fmt.Fprintf(w, "Error: %v\n", err)
+}
+
+// renderYAML goes through JSON first so the json struct tags name the keys
+// and the field order is kept.
+func renderYAML(w io.Writer, result any) error {
+	body, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	node := yaml.Node{}
+	err = yaml.Unmarshal(body, &node)
+	if err != nil {
+		return err
+	}
+	blockStyle(&node)
+
+	// Start every result with a document marker so a stream of commands
+	// stays one YAML document per command.
+	_, err = fmt.Fprintln(w, "---")
+	if err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	return encoder.Encode(&node)
+}
+
+// blockStyle clears the flow style yaml.v3 keeps from the JSON input so the
+// output reads as ordinary block YAML.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+func renderTable(w io.Writer, table tableRenderer) {
+	header, rows := table.tableRows()
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw)
+	writeRow(tw, header)
+	for _, row := range rows {
+		writeRow(tw, row)
+	}
+	tw.Flush()
+	fmt.Fprintln(w)
+}
+
+func writeRow(w io.Writer, row []string) {
+	for i, cell := range row {
+		if i > 0 {
+			fmt.Fprint(w, "\t")
+		}
+		fmt.Fprint(w, cell)
+	}
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type flagKind int
+
+const (
+	flagString flagKind = iota
+	flagInt
+	flagBool
+)
+
+// argSpec describes a positional argument of a command.
+type argSpec struct {
+	name     string
+	optional bool
+	// variadic collects every remaining positional argument.
+	variadic bool
+	// raw keeps the argument's case, e.g. for file names.
+	raw bool
+	// complete lists tab completion candidates given the positional
+	// arguments before this one.
+	complete func(config *Config, before []string) []string
+}
+
+// flagSpec describes an optional "--name value" flag of a command.
+type flagSpec struct {
+	name  string
+	kind  flagKind
+	value string
+	def   string
+	usage string
+	// lower folds the case of the value, for flags that take a name such
+	// as a ball or a game. Other values, like nicknames, are kept as given.
+	lower bool
+}
+
+// cmdArgs holds the validated arguments a command callback receives.
+type cmdArgs struct {
+	positional []string
+	flags      map[string]string
+}
+
+// arg returns the i-th positional argument, or "" if it was not given.
+func (a cmdArgs) arg(i int) string {
+	if i < len(a.positional) {
+		return a.positional[i]
+	}
+	return ""
+}
+
+func (a cmdArgs) flag(name string) string {
+	return a.flags[name]
+}
+
+// intFlag returns an int flag. Values were checked when the arguments were
+// parsed, so the error can be ignored.
+func (a cmdArgs) intFlag(name string) int {
+	n, _ := strconv.Atoi(a.flags[name])
+	return n
+}
+
+func (a cmdArgs) boolFlag(name string) bool {
+	return a.flags[name] == "true"
+}
+
+// usageError is returned when a command line does not match the command's
+// argument spec.
+type usageError struct {
+	command cliCommand
+	msg     string
+}
+
+func (e usageError) Error() string {
+	return fmt.Sprintf("%s\nusage: %s", e.msg, e.command.usage())
+}
+
+// usage builds the synopsis of a command from its argument spec.
+func (c cliCommand) usage() string {
+	parts := []string{c.name}
+
+	for _, arg := range c.args {
+		name := arg.name
+		if arg.variadic {
+			name += "..."
+		}
+		if arg.optional {
+			name = "[" + name + "]"
+		}
+		parts = append(parts, name)
+	}
+
+	for _, f := range c.flags {
+		if f.kind == flagBool {
+			parts = append(parts, fmt.Sprintf("[--%s]", f.name))
+		} else {
+			parts = append(parts, fmt.Sprintf("[--%s %s]", f.name, f.value))
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// tokenize splits a command line on whitespace. Single quotes keep their
+// contents literally, double quotes allow backslash escapes, and a backslash
+// outside quotes escapes the next character.
+func tokenize(line string) ([]string, error) {
+	tokens := []string{}
+	current := strings.Builder{}
+	inToken := false
+	var quote rune
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else if r == '\\' && i+1 < len(runes) {
+				i++
+				current.WriteRune(runes[i])
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inToken = true
+		case r == '\\':
+			if i+1 < len(runes) {
+				i++
+				current.WriteRune(runes[i])
+			}
+			inToken = true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				inToken = false
+			}
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if inToken {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens, nil
+}
+
+// parseArgs validates tokens against the command's spec, filling in flag
+// defaults and folding the case of positional arguments unless marked raw,
+// and of flag values marked lower.
+func parseArgs(cmd cliCommand, tokens []string) (cmdArgs, error) {
+	args := cmdArgs{
+		positional: []string{},
+		flags:      map[string]string{},
+	}
+	for _, f := range cmd.flags {
+		args.flags[f.name] = f.def
+		if f.kind == flagBool && f.def == "" {
+			args.flags[f.name] = "false"
+		}
+	}
+
+	positional := []string{}
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+
+		if token == "--" {
+			positional = append(positional, tokens[i+1:]...)
+			break
+		}
+
+		name, isFlag := strings.CutPrefix(token, "--")
+		if !isFlag || name == "" {
+			positional = append(positional, token)
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(name, "=")
+		spec, ok := cmd.flag(name)
+		if !ok {
+			return args, usageError{cmd, fmt.Sprintf("unknown flag --%s", name)}
+		}
+
+		if spec.kind == flagBool {
+			if !hasValue {
+				value = "true"
+			}
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return args, usageError{cmd, fmt.Sprintf("--%s expects true or false", name)}
+			}
+			args.flags[name] = strconv.FormatBool(b)
+			continue
+		}
+
+		if !hasValue {
+			if i+1 >= len(tokens) {
+				return args, usageError{cmd, fmt.Sprintf("--%s needs a value", name)}
+			}
+			i++
+			value = tokens[i]
+		}
+
+		if spec.kind == flagInt {
+			if _, err := strconv.Atoi(value); err != nil {
+				return args, usageError{cmd, fmt.Sprintf("--%s expects a number, got %q", name, value)}
+			}
+		}
+
+		if spec.lower {
+			value = strings.ToLower(value)
+		}
+		args.flags[name] = value
+	}
+
+	for i, spec := range cmd.args {
+		if i >= len(positional) {
+			if !spec.optional {
+				return args, usageError{cmd, fmt.Sprintf("missing %s", spec.name)}
+			}
+			break
+		}
+
+		if spec.variadic {
+			for _, value := range positional[i:] {
+				args.positional = append(args.positional, foldArg(spec, value))
+			}
+			return args, nil
+		}
+
+		args.positional = append(args.positional, foldArg(spec, positional[i]))
+	}
+
+	if len(positional) > len(cmd.args) {
+		return args, usageError{cmd, fmt.Sprintf("unexpected argument %q", positional[len(cmd.args)])}
+	}
+
+	return args, nil
+}
+
+func foldArg(spec argSpec, value string) string {
+	if spec.raw {
+		return value
+	}
+	return strings.ToLower(value)
+}
+
+func (c cliCommand) flag(name string) (flagSpec, bool) {
+	for _, f := range c.flags {
+		if f.name == name {
+			return f, true
+		}
+	}
+	return flagSpec{}, false
+}
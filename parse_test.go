@@ -0,0 +1,189 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected []string
+		err      bool
+	}{
+		{
+			input:    "  catch   pikachu ",
+			expected: []string{"catch", "pikachu"},
+		},
+		{
+			input:    "",
+			expected: []string{},
+		},
+		{
+			input:    "nickname 1 'Sparky the \"Great\"'",
+			expected: []string{"nickname", "1", `Sparky the "Great"`},
+		},
+		{
+			input:    `alias grind "explore \"mt-coronet\"; catch geodude"`,
+			expected: []string{"alias", "grind", `explore "mt-coronet"; catch geodude`},
+		},
+		{
+			input:    `run my\ script.txt`,
+			expected: []string{"run", "my script.txt"},
+		},
+		{
+			input:    `nickname 1 ''`,
+			expected: []string{"nickname", "1", ""},
+		},
+		{
+			input:    "a\tb\nc",
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			input: `nickname 1 "Sparky`,
+			err:   true,
+		},
+		{
+			input: "nickname 1 'Sparky",
+			err:   true,
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			actual, err := tokenize(c.input)
+			if c.err {
+				if err == nil {
+					t.Errorf("Expected an error for %q, got %q", c.input, actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error for %q: %v", c.input, err)
+			}
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("Expected %q, got %q", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	cmd := cliCommand{
+		name: "test",
+		args: []argSpec{
+			{name: "NAME"},
+			{name: "FILE", optional: true, raw: true},
+			{name: "MORE", optional: true, variadic: true},
+		},
+		flags: []flagSpec{
+			{name: "ball", value: "BALL", def: "poke-ball", lower: true},
+			{name: "nickname", value: "NICKNAME"},
+			{name: "level", kind: flagInt, value: "LEVEL", def: "100"},
+			{name: "skip", kind: flagBool},
+		},
+	}
+
+	defaults := map[string]string{"ball": "poke-ball", "nickname": "", "level": "100", "skip": "false"}
+
+	// withFlags returns the default flags with some changed.
+	withFlags := func(changed map[string]string) map[string]string {
+		flags := map[string]string{}
+		for name, value := range defaults {
+			flags[name] = value
+		}
+		for name, value := range changed {
+			flags[name] = value
+		}
+		return flags
+	}
+
+	cases := []struct {
+		input    []string
+		expected cmdArgs
+		// usage is the usage error message expected, if any.
+		usage string
+	}{
+		{
+			input:    []string{"Pikachu"},
+			expected: cmdArgs{positional: []string{"pikachu"}, flags: defaults},
+		},
+		{
+			input:    []string{"pikachu", "My-File.txt", "A", "B"},
+			expected: cmdArgs{positional: []string{"pikachu", "My-File.txt", "a", "b"}, flags: defaults},
+		},
+		{
+			input:    []string{"pikachu", "--ball", "Great-Ball", "--nickname", "Sparky"},
+			expected: cmdArgs{positional: []string{"pikachu"}, flags: withFlags(map[string]string{"ball": "great-ball", "nickname": "Sparky"})},
+		},
+		{
+			input:    []string{"--nickname=Mr Mime", "--level=50", "pikachu"},
+			expected: cmdArgs{positional: []string{"pikachu"}, flags: withFlags(map[string]string{"nickname": "Mr Mime", "level": "50"})},
+		},
+		{
+			input:    []string{"pikachu", "--skip"},
+			expected: cmdArgs{positional: []string{"pikachu"}, flags: withFlags(map[string]string{"skip": "true"})},
+		},
+		{
+			input:    []string{"pikachu", "--skip=false"},
+			expected: cmdArgs{positional: []string{"pikachu"}, flags: defaults},
+		},
+		{
+			input:    []string{"--", "--ball", "x"},
+			expected: cmdArgs{positional: []string{"--ball", "x"}, flags: defaults},
+		},
+		{
+			input: []string{},
+			usage: "missing NAME",
+		},
+		{
+			input: []string{"pikachu", "--shiny"},
+			usage: "unknown flag --shiny",
+		},
+		{
+			input: []string{"pikachu", "--ball"},
+			usage: "--ball needs a value",
+		},
+		{
+			input: []string{"pikachu", "--level", "high"},
+			usage: `--level expects a number, got "high"`,
+		},
+		{
+			input: []string{"pikachu", "--skip=maybe"},
+			usage: "--skip expects true or false",
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			actual, err := parseArgs(cmd, c.input)
+			if c.usage != "" {
+				var usage usageError
+				if !errors.As(err, &usage) {
+					t.Fatalf("Expected usage error %q, got %v", c.usage, err)
+				}
+				if usage.msg != c.usage {
+					t.Errorf("Expected usage error %q, got %q", c.usage, usage.msg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("Expected %+v, got %+v", c.expected, actual)
+			}
+		})
+	}
+
+	t.Run("Too many arguments", func(t *testing.T) {
+		cmd := cliCommand{name: "one", args: []argSpec{{name: "NAME"}}}
+		_, err := parseArgs(cmd, []string{"pikachu", "raichu"})
+		var usage usageError
+		if !errors.As(err, &usage) || usage.msg != `unexpected argument "raichu"` {
+			t.Errorf("Expected an unexpected argument error, got %v", err)
+		}
+	})
+}
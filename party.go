@@ -0,0 +1,306 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+)
+
+const (
+	partySize = 6
+	boxSize   = 30
+	// maxNickname is the longest nickname the games allow.
+	maxNickname = 12
+)
+
+// slot is where one of the player's Pokemon is kept: party slot index when
+// box is 0, otherwise slot index of box number box.
+type slot struct {
+	box   int
+	index int
+}
+
+func (s slot) String() string {
+	if s.box == 0 {
+		return "your party"
+	}
+	return fmt.Sprintf("box %d", s.box)
+}
+
+type partyMember struct {
+	Slot    int    `json:"slot"`
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Species string `json:"species"`
+	Level   int    `json:"level"`
+}
+
+type partyResult struct {
+	Pokemon []partyMember `json:"pokemon"`
+}
+
+func (r partyResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Your party:")
+	renderMembers(w, r.Pokemon)
+	fmt.Fprintln(w)
+}
+
+func (r partyResult) tableRows() ([]string, [][]string) {
+	return memberRows(r.Pokemon)
+}
+
+type boxResult struct {
+	Box     int           `json:"box"`
+	Boxes   int           `json:"boxes"`
+	Pokemon []partyMember `json:"pokemon"`
+}
+
+func (r boxResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Box %d of %d:\n", r.Box, r.Boxes)
+	renderMembers(w, r.Pokemon)
+	fmt.Fprintln(w)
+}
+
+func (r boxResult) tableRows() ([]string, [][]string) {
+	return memberRows(r.Pokemon)
+}
+
+func renderMembers(w io.Writer, members []partyMember) {
+	if len(members) == 0 {
+		fmt.Fprintln(w, "   empty")
+	}
+
+	for _, member := range members {
+		name := member.Name
+		if name != member.Species {
+			name += " (" + member.Species + ")"
+		}
+		fmt.Fprintf(w, "   %2d. #%d %s, Lv %d\n", member.Slot, member.ID, name, member.Level)
+	}
+}
+
+func memberRows(members []partyMember) ([]string, [][]string) {
+	rows := [][]string{}
+	for _, member := range members {
+		rows = append(rows, []string{
+			strconv.Itoa(member.Slot),
+			strconv.Itoa(member.ID),
+			member.Name,
+			member.Species,
+			strconv.Itoa(member.Level),
+		})
+	}
+	return []string{"SLOT", "ID", "NAME", "SPECIES", "LEVEL"}, rows
+}
+
+func (config *Config) newPartyMember(slot int, id int) partyMember {
+	caught := config.Owned[id]
+	return partyMember{Slot: slot, ID: id, Name: caught.Name(), Species: caught.Species, Level: caught.Level}
+}
+
+// locate finds the slot a Pokemon is kept in.
+func (config *Config) locate(id int) (slot, bool) {
+	if i := slices.Index(config.Party, id); i >= 0 {
+		return slot{index: i}, true
+	}
+	for b, box := range config.Boxes {
+		if i := slices.Index(box, id); i >= 0 {
+			return slot{box: b + 1, index: i}, true
+		}
+	}
+	return slot{}, false
+}
+
+func (config *Config) setSlot(s slot, id int) {
+	if s.box == 0 {
+		config.Party[s.index] = id
+		return
+	}
+	config.Boxes[s.box-1][s.index] = id
+}
+
+// freeBoxSlot returns the first empty box slot, adding a box when they are
+// all full.
+func (config *Config) freeBoxSlot() slot {
+	for b, box := range config.Boxes {
+		if i := slices.Index(box, 0); i >= 0 {
+			return slot{box: b + 1, index: i}
+		}
+	}
+
+	config.Boxes = append(config.Boxes, make([]int, boxSize))
+	return slot{box: len(config.Boxes), index: 0}
+}
+
+// store puts a Pokemon in the party when there is room, otherwise in the
+// first free box slot.
+func (config *Config) store(id int) slot {
+	if len(config.Party) < partySize {
+		config.Party = append(config.Party, id)
+		return slot{index: len(config.Party) - 1}
+	}
+
+	s := config.freeBoxSlot()
+	config.setSlot(s, id)
+	return s
+}
+
+// unstore takes a Pokemon out of the party or its box.
+func (config *Config) unstore(id int) {
+	s, ok := config.locate(id)
+	if !ok {
+		return
+	}
+	if s.box == 0 {
+		config.Party = slices.Delete(config.Party, s.index, s.index+1)
+		return
+	}
+	config.setSlot(s, 0)
+}
+
+var errLastPartyMember = errors.New("you can't leave your party empty.")
+
+func commandParty(config *Config, args cmdArgs) (any, error) {
+	action := args.arg(0)
+	if action != "" && config.Battle != nil {
+		return nil, errInBattle
+	}
+
+	ids := args.positional[min(1, len(args.positional)):]
+
+	wantIDs := map[string]int{"": 0, "add": 1, "remove": 1, "swap": 2}
+	want, ok := wantIDs[action]
+	if !ok {
+		return nil, usageError{commands["party"], fmt.Sprintf("unknown action %q", action)}
+	}
+	if len(ids) != want {
+		return nil, usageError{commands["party"], fmt.Sprintf("%q needs %d pokemon", action, want)}
+	}
+
+	pokemon := []*CaughtPokemon{}
+	for _, id := range ids {
+		caught, err := config.findOwned(id)
+		if err != nil {
+			return nil, err
+		}
+		pokemon = append(pokemon, caught)
+	}
+
+	switch action {
+	case "add":
+		s, _ := config.locate(pokemon[0].ID)
+		if s.box == 0 {
+			return nil, fmt.Errorf("%s is already in your party.", pokemon[0].Name())
+		}
+		if len(config.Party) >= partySize {
+			return nil, errors.New("your party is full, remove or swap a pokemon first.")
+		}
+		config.unstore(pokemon[0].ID)
+		config.Party = append(config.Party, pokemon[0].ID)
+	case "remove":
+		s, _ := config.locate(pokemon[0].ID)
+		if s.box != 0 {
+			return nil, fmt.Errorf("%s is not in your party.", pokemon[0].Name())
+		}
+		if len(config.Party) == 1 {
+			return nil, errLastPartyMember
+		}
+		config.unstore(pokemon[0].ID)
+		config.setSlot(config.freeBoxSlot(), pokemon[0].ID)
+	case "swap":
+		a, _ := config.locate(pokemon[0].ID)
+		b, _ := config.locate(pokemon[1].ID)
+		config.setSlot(a, pokemon[1].ID)
+		config.setSlot(b, pokemon[0].ID)
+	}
+
+	result := partyResult{Pokemon: []partyMember{}}
+	for i, id := range config.Party {
+		result.Pokemon = append(result.Pokemon, config.newPartyMember(i+1, id))
+	}
+
+	return result, nil
+}
+
+func commandBox(config *Config, args cmdArgs) (any, error) {
+	number := 1
+	if arg := args.arg(0); arg != "" {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 1 || n > max(1, len(config.Boxes)) {
+			return nil, fmt.Errorf("there is no box %s.", arg)
+		}
+		number = n
+	}
+
+	result := boxResult{Box: number, Boxes: max(1, len(config.Boxes)), Pokemon: []partyMember{}}
+	if number <= len(config.Boxes) {
+		for i, id := range config.Boxes[number-1] {
+			if id != 0 {
+				result.Pokemon = append(result.Pokemon, config.newPartyMember(i+1, id))
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func commandRelease(config *Config, args cmdArgs) (any, error) {
+	if config.Battle != nil {
+		return nil, errInBattle
+	}
+
+	caught, err := config.findOwned(args.arg(0))
+	if err != nil {
+		return nil, err
+	}
+
+	if s, _ := config.locate(caught.ID); s.box == 0 && len(config.Party) == 1 {
+		return nil, errLastPartyMember
+	}
+
+	config.unstore(caught.ID)
+	delete(config.Owned, caught.ID)
+
+	return message{Message: fmt.Sprintf("%s was released. Bye, %s!", caught.Name(), caught.Name())}, nil
+}
+
+func commandNickname(config *Config, args cmdArgs) (any, error) {
+	caught, err := config.findOwned(args.arg(0))
+	if err != nil {
+		return nil, err
+	}
+
+	nickname := args.arg(1)
+	if len([]rune(nickname)) > maxNickname {
+		return nil, fmt.Errorf("nicknames can be at most %d characters long.", maxNickname)
+	}
+
+	old := caught.Name()
+	caught.Nickname = nickname
+
+	if nickname == "" {
+		return message{Message: fmt.Sprintf("%s's nickname was removed.", old)}, nil
+	}
+	return message{Message: fmt.Sprintf("%s is now called %s.", old, nickname)}, nil
+}
+
+var partyActions = []string{"add", "remove", "swap"}
+
+func completePartyArgs(config *Config, before []string) []string {
+	if len(before) == 0 {
+		return partyActions
+	}
+	return completeCaught(config, before)
+}
+
+func completeBoxes(config *Config, before []string) []string {
+	numbers := []string{}
+	for i := range config.Boxes {
+		numbers = append(numbers, strconv.Itoa(i+1))
+	}
+	return numbers
+}
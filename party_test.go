@@ -0,0 +1,224 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// testStorage returns a Config owning a caught Pikachu for every ID kept in
+// the party and boxes.
+func testStorage(t *testing.T, party []int, boxes [][]int) *Config {
+	config := newTestConfig(t, 1)
+	config.Party = party
+	config.Boxes = boxes
+
+	ids := append([]int{}, party...)
+	for _, b := range boxes {
+		ids = append(ids, b...)
+	}
+	for _, id := range ids {
+		if id != 0 {
+			config.Owned[id] = testCaught(t, id)
+		}
+	}
+	return config
+}
+
+// box returns a box holding ids in its first slots.
+func box(ids ...int) []int {
+	return append(ids, make([]int, boxSize-len(ids))...)
+}
+
+func TestCommandParty(t *testing.T) {
+	commands = getCommands()
+
+	cases := []struct {
+		party         []int
+		boxes         [][]int
+		input         []string
+		expectedParty []int
+		expectedBoxes [][]int
+		err           string
+		usage         bool
+	}{
+		{
+			party:         []int{1, 2, 3},
+			input:         []string{"swap", "1", "3"},
+			expectedParty: []int{3, 2, 1},
+		},
+		{
+			// Swapping with a boxed Pokemon keeps both slots.
+			party:         []int{1, 2},
+			boxes:         [][]int{box(0, 3)},
+			input:         []string{"swap", "1", "3"},
+			expectedParty: []int{3, 2},
+			expectedBoxes: [][]int{box(0, 1)},
+		},
+		{
+			party:         []int{1},
+			boxes:         [][]int{box(2, 3)},
+			input:         []string{"add", "3"},
+			expectedParty: []int{1, 3},
+			expectedBoxes: [][]int{box(2)},
+		},
+		{
+			// A removed Pokemon goes to the first free box slot.
+			party:         []int{1, 2, 3},
+			boxes:         [][]int{box(0, 4)},
+			input:         []string{"remove", "2"},
+			expectedParty: []int{1, 3},
+			expectedBoxes: [][]int{box(2, 4)},
+		},
+		{
+			party: []int{1, 2},
+			input: []string{"add", "2"},
+			err:   "pikachu is already in your party.",
+		},
+		{
+			party: []int{1, 2, 3, 4, 5, 6},
+			boxes: [][]int{box(7)},
+			input: []string{"add", "7"},
+			err:   "your party is full, remove or swap a pokemon first.",
+		},
+		{
+			party: []int{1},
+			boxes: [][]int{box(2)},
+			input: []string{"remove", "2"},
+			err:   "pikachu is not in your party.",
+		},
+		{
+			party: []int{1},
+			input: []string{"remove", "1"},
+			err:   errLastPartyMember.Error(),
+		},
+		{
+			party: []int{1},
+			input: []string{"swap", "1", "9"},
+			err:   "you have no pokemon with ID 9.",
+		},
+		{
+			party: []int{1, 2},
+			input: []string{"swap", "1"},
+			usage: true,
+		},
+		{
+			party: []int{1},
+			input: []string{"trade", "1"},
+			usage: true,
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			config := testStorage(t, c.party, c.boxes)
+			result, err := commandParty(config, cmdArgs{positional: c.input})
+			if c.usage {
+				var usage usageError
+				if !errors.As(err, &usage) {
+					t.Errorf("Expected a usage error, got %v", err)
+				}
+				return
+			}
+			if c.err != "" {
+				if err == nil || err.Error() != c.err {
+					t.Errorf("Expected error %q, got %v", c.err, err)
+				}
+				if result != nil {
+					t.Errorf("Expected no result with an error, got %+v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(config.Party, c.expectedParty) {
+				t.Errorf("Expected party %v, got %v", c.expectedParty, config.Party)
+			}
+			if !reflect.DeepEqual(config.Boxes, c.expectedBoxes) {
+				t.Errorf("Expected boxes %v, got %v", c.expectedBoxes, config.Boxes)
+			}
+
+			members := result.(partyResult).Pokemon
+			if len(members) != len(c.expectedParty) {
+				t.Fatalf("Expected %v party members, got %+v", len(c.expectedParty), members)
+			}
+			for i, member := range members {
+				if member.Slot != i+1 || member.ID != c.expectedParty[i] {
+					t.Errorf("Expected #%d in slot %d, got %+v", c.expectedParty[i], i+1, member)
+				}
+			}
+		})
+	}
+}
+
+func TestCommandRelease(t *testing.T) {
+	cases := []struct {
+		party         []int
+		boxes         [][]int
+		input         int
+		expectedParty []int
+		expectedBoxes [][]int
+		err           string
+	}{
+		{
+			party:         []int{1, 2, 3},
+			input:         2,
+			expectedParty: []int{1, 3},
+		},
+		{
+			party:         []int{1},
+			boxes:         [][]int{box(2, 3)},
+			input:         2,
+			expectedParty: []int{1},
+			expectedBoxes: [][]int{box(0, 3)},
+		},
+		{
+			// The last party member can't go, even with more in the boxes.
+			party: []int{1},
+			boxes: [][]int{box(2)},
+			input: 1,
+			err:   errLastPartyMember.Error(),
+		},
+		{
+			party: []int{1},
+			input: 9,
+			err:   "you have no pokemon with ID 9.",
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			config := testStorage(t, c.party, c.boxes)
+			owned := len(config.Owned)
+
+			_, err := commandRelease(config, cmdArgs{positional: []string{strconv.Itoa(c.input)}})
+			if c.err != "" {
+				if err == nil || err.Error() != c.err {
+					t.Errorf("Expected error %q, got %v", c.err, err)
+				}
+				if len(config.Owned) != owned {
+					t.Errorf("Expected nothing to be released")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(config.Party, c.expectedParty) {
+				t.Errorf("Expected party %v, got %v", c.expectedParty, config.Party)
+			}
+			if !reflect.DeepEqual(config.Boxes, c.expectedBoxes) {
+				t.Errorf("Expected boxes %v, got %v", c.expectedBoxes, config.Boxes)
+			}
+			if _, ok := config.Owned[c.input]; ok {
+				t.Errorf("Expected #%d to be gone", c.input)
+			}
+			if len(config.Owned) != owned-1 {
+				t.Errorf("Expected one pokemon released, have %v of %v", len(config.Owned), owned)
+			}
+		})
+	}
+}
@@ -1,12 +1,18 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"path"
+	"strconv"
+	"strings"
 )
 
+var errNotFound = errors.New("not found")
+
 func httpGet(url string) ([]byte, error) {
 	res, err := http.Get(url)
 	if err != nil {
@@ -14,6 +20,9 @@ func httpGet(url string) ([]byte, error) {
 	}
 	body, err := io.ReadAll(res.Body)
 	res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s (404)", errNotFound, url)
+	}
 	if res.StatusCode > 299 {
 		return nil, errors.New(fmt.Sprintf("Response failed with status code: %d and\nbody: %s\n", res.StatusCode, body))
 	}
@@ -23,3 +32,81 @@ func httpGet(url string) ([]byte, error) {
 
 	return body, nil
 }
+
+// fetch gets url from the cache, or from the API if it is not cached yet, and
+// decodes the JSON body into v.
+func (config *Config) fetch(url string, v any) error {
+	var err error
+
+	body, ok := config.Cache.Get(url)
+	if !ok {
+		body, err = httpGet(url)
+		if err != nil {
+			return err
+		}
+
+		err = config.Cache.Add(url, body)
+		if err != nil {
+			return err
+		}
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+func (config *Config) getArea(name string) (Explore, error) {
+	area := Explore{}
+	err := config.fetch(config.Explore+name, &area)
+	if errors.Is(err, errNotFound) {
+		return area, errors.New("invalid area name.")
+	}
+	return area, err
+}
+
+func (config *Config) getPokemon(name string) (Pokemon, error) {
+	pokemon := Pokemon{}
+	err := config.fetch(config.Pokemon+name, &pokemon)
+	if errors.Is(err, errNotFound) {
+		return pokemon, errors.New("invalid pokemon name.")
+	}
+	return pokemon, err
+}
+
+// getSpecies looks name up as a species, falling back to the species of the
+// Pokemon called name so forms like "giratina-origin" work too.
+func (config *Config) getSpecies(name string) (PokemonSpecies, error) {
+	species := PokemonSpecies{}
+	err := config.fetch(config.Species+name, &species)
+	if !errors.Is(err, errNotFound) {
+		return species, err
+	}
+
+	pokemon, err := config.getPokemon(name)
+	if err != nil {
+		return species, errors.New("invalid species name.")
+	}
+
+	return config.getSpeciesOf(pokemon)
+}
+
+func (config *Config) getRegionalDex(name string) (RegionalPokedex, error) {
+	dex := RegionalPokedex{}
+	err := config.fetch(config.Dex+name, &dex)
+	if errors.Is(err, errNotFound) {
+		return dex, errors.New("invalid pokedex name.")
+	}
+	return dex, err
+}
+
+// resourceID returns the ID at the end of an API resource URL, or 0.
+func resourceID(url string) int {
+	id, _ := strconv.Atoi(path.Base(strings.TrimSuffix(url, "/")))
+	return id
+}
+
+// getSpeciesOf follows the species link of a Pokemon.
+func (config *Config) getSpeciesOf(pokemon Pokemon) (PokemonSpecies, error) {
+	species := PokemonSpecies{}
+	err := config.fetch(pokemon.Species.URL, &species)
+	return species, err
+}
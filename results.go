@@ -0,0 +1,306 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type locationList struct {
+	Locations []string `json:"locations"`
+}
+
+func newLocationList(result mapResult) locationList {
+	list := locationList{Locations: []string{}}
+	for _, item := range result.Results {
+		list.Locations = append(list.Locations, item.Name)
+	}
+	return list
+}
+
+func (r locationList) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+
+	for _, name := range r.Locations {
+		fmt.Fprintln(w, name)
+	}
+
+	fmt.Fprintln(w)
+}
+
+func (r locationList) tableRows() ([]string, [][]string) {
+	rows := [][]string{}
+	for _, name := range r.Locations {
+		rows = append(rows, []string{name})
+	}
+	return []string{"LOCATION AREA"}, rows
+}
+
+type exploreResult struct {
+	Area    string   `json:"area"`
+	Pokemon []string `json:"pokemon"`
+	Found   string   `json:"found,omitempty"`
+}
+
+func (r exploreResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Exploring "+r.Area+"...")
+	fmt.Fprintln(w, "Found Pokemon:")
+	fmt.Fprintln(w)
+
+	for _, name := range r.Pokemon {
+		fmt.Fprintln(w, " - "+name)
+	}
+
+	if r.Found != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "You found a %s! It was put in your bag.\n", r.Found)
+	}
+
+	fmt.Fprintln(w)
+}
+
+func (r exploreResult) tableRows() ([]string, [][]string) {
+	rows := [][]string{}
+	for _, name := range r.Pokemon {
+		rows = append(rows, []string{r.Area, name})
+	}
+	return []string{"AREA", "POKEMON"}, rows
+}
+
+type catchResult struct {
+	ID         int             `json:"id,omitempty"`
+	Pokemon    string          `json:"pokemon"`
+	Level      int             `json:"level"`
+	Ball       string          `json:"ball"`
+	Shakes     int             `json:"shakes"`
+	Caught     bool            `json:"caught"`
+	Fled       bool            `json:"fled"`
+	SentTo     string          `json:"sent_to,omitempty"`
+	Experience *experienceGain `json:"experience,omitempty"`
+	// Battle is the wild Pokemon's turn after it broke free in a battle.
+	Battle *battleResult `json:"battle,omitempty"`
+}
+
+func (r catchResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Throwing a %s at %s (Lv %d)\n", r.Ball, r.Pokemon, r.Level)
+
+	// The ball wobbles at most three times before it clicks shut.
+	for i := 0; i < min(r.Shakes, 3); i++ {
+		fmt.Fprintln(w, "...the ball wobbles...")
+	}
+
+	if r.Caught {
+		fmt.Fprintf(w, "%s was caught! It was given ID %d and sent to %s.\n", r.Pokemon, r.ID, r.SentTo)
+		fmt.Fprintln(w, "you may now inspect it with the inspect command.")
+		if r.Experience != nil {
+			r.Experience.render(w)
+		}
+	} else {
+		fmt.Fprintf(w, "%s escaped!\n", r.Pokemon)
+	}
+
+	if r.Fled {
+		fmt.Fprintf(w, "%s fled!\n", r.Pokemon)
+	}
+
+	if r.Battle != nil {
+		r.Battle.renderTurn(w)
+	}
+
+	fmt.Fprintln(w)
+}
+
+type statInfo struct {
+	Name     string `json:"name"`
+	BaseStat int    `json:"base_stat"`
+	// Value is the stat of a caught Pokemon, and Min and Max the range the
+	// stat can have at the pokemonInfo's RangeLevel.
+	Value int `json:"value,omitempty"`
+	Min   int `json:"min,omitempty"`
+	Max   int `json:"max,omitempty"`
+}
+
+type abilityInfo struct {
+	Name   string `json:"name"`
+	Hidden bool   `json:"hidden"`
+}
+
+type pokemonInfo struct {
+	Name      string        `json:"name"`
+	Height    int           `json:"height"`
+	Weight    int           `json:"weight"`
+	Stats     []statInfo    `json:"stats"`
+	Types     []string      `json:"types"`
+	Abilities []abilityInfo `json:"abilities"`
+	Species   *speciesInfo  `json:"species,omitempty"`
+	// Instance holds the individual traits of one of the player's Pokemon.
+	Instance   *CaughtPokemon `json:"instance,omitempty"`
+	RangeLevel int            `json:"range_level,omitempty"`
+}
+
+func newPokemonInfo(pokemon Pokemon) pokemonInfo {
+	info := pokemonInfo{
+		Name:      pokemon.Name,
+		Height:    pokemon.Height,
+		Weight:    pokemon.Weight,
+		Stats:     []statInfo{},
+		Types:     []string{},
+		Abilities: []abilityInfo{},
+	}
+
+	for _, stat := range pokemon.Stats {
+		info.Stats = append(info.Stats, statInfo{Name: stat.Stat.Name, BaseStat: stat.BaseStat})
+	}
+
+	for _, types := range pokemon.Types {
+		info.Types = append(info.Types, types.Type.Name)
+	}
+
+	for _, ability := range pokemon.Abilities {
+		info.Abilities = append(info.Abilities, abilityInfo{Name: ability.Ability.Name, Hidden: ability.IsHidden})
+	}
+
+	return info
+}
+
+func (r pokemonInfo) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	if r.Instance != nil {
+		fmt.Fprintf(w, "ID: %d\n", r.Instance.ID)
+		if r.Instance.Nickname != "" {
+			fmt.Fprintf(w, "Nickname: %s\n", r.Instance.Nickname)
+		}
+	}
+	fmt.Fprintf(w, "Name: %s\n", r.Name)
+	fmt.Fprintf(w, "Height: %d\n", r.Height)
+	fmt.Fprintf(w, "Weight: %d\n", r.Weight)
+	if r.Instance != nil {
+		r.Instance.renderTraits(w)
+	}
+	fmt.Fprintln(w, "Stats:")
+
+	for _, stat := range r.Stats {
+		fmt.Fprintf(w, "   -%s: %s\n", stat.Name, r.describeStat(stat))
+	}
+
+	fmt.Fprintln(w, "Types:")
+
+	for _, name := range r.Types {
+		fmt.Fprintf(w, "   - %s\n", name)
+	}
+
+	fmt.Fprintln(w, "Abilities:")
+
+	for _, ability := range r.Abilities {
+		if ability.Hidden {
+			fmt.Fprintf(w, "   - %s (hidden)\n", ability.Name)
+		} else {
+			fmt.Fprintf(w, "   - %s\n", ability.Name)
+		}
+	}
+
+	if r.Species != nil {
+		fmt.Fprintf(w, "Species: %s, the %s\n", r.Species.Name, r.Species.Genus)
+		r.Species.renderDetails(w)
+	}
+
+	fmt.Fprintln(w)
+}
+
+func (r pokemonInfo) tableRows() ([]string, [][]string) {
+	rows := [][]string{}
+	if r.Instance != nil {
+		rows = append(rows, []string{"id", strconv.Itoa(r.Instance.ID)}, []string{"nickname", r.Instance.Nickname})
+	}
+	rows = append(rows,
+		[]string{"name", r.Name},
+		[]string{"height", strconv.Itoa(r.Height)},
+		[]string{"weight", strconv.Itoa(r.Weight)},
+	)
+	if r.Instance != nil {
+		rows = append(rows, r.Instance.rows()...)
+	}
+	for _, stat := range r.Stats {
+		rows = append(rows, []string{stat.Name, r.describeStat(stat)})
+	}
+	for _, name := range r.Types {
+		rows = append(rows, []string{"type", name})
+	}
+	for _, ability := range r.Abilities {
+		if ability.Hidden {
+			rows = append(rows, []string{"ability", ability.Name + " (hidden)"})
+		} else {
+			rows = append(rows, []string{"ability", ability.Name})
+		}
+	}
+	if r.Species != nil {
+		rows = append(rows, r.Species.rows()...)
+	}
+	return []string{"FIELD", "VALUE"}, rows
+}
+
+// describeStat shows the base stat, along with the actual stat and its range
+// for caught Pokemon.
+func (r pokemonInfo) describeStat(stat statInfo) string {
+	if r.Instance == nil {
+		return strconv.Itoa(stat.BaseStat)
+	}
+	return fmt.Sprintf("%d (base %d, %d-%d at level %d)", stat.Value, stat.BaseStat, stat.Min, stat.Max, r.RangeLevel)
+}
+
+type pokedexEntry struct {
+	Number  int    `json:"number"`
+	Species string `json:"species"`
+	// Status is dexCaught or dexSeen.
+	Status string `json:"status"`
+	Owned  []int  `json:"owned"`
+}
+
+type pokedexResult struct {
+	Pokemon []pokedexEntry `json:"pokemon"`
+}
+
+func (r pokedexResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+
+	if len(r.Pokemon) == 0 {
+		fmt.Fprintln(w, "you have not seen any pokemon.")
+		fmt.Fprintln(w)
+		return
+	}
+
+	fmt.Fprintln(w, "Your Pokedex:")
+
+	for _, entry := range r.Pokemon {
+		if entry.Status == dexSeen {
+			fmt.Fprintf(w, "   #%03d %s (seen)\n", entry.Number, entry.Species)
+		} else {
+			fmt.Fprintf(w, "   #%03d %s %s\n", entry.Number, entry.Species, idList(entry.Owned))
+		}
+	}
+
+	fmt.Fprintln(w)
+}
+
+func (r pokedexResult) tableRows() ([]string, [][]string) {
+	rows := [][]string{}
+	for _, entry := range r.Pokemon {
+		rows = append(rows, []string{strconv.Itoa(entry.Number), entry.Species, entry.Status, idList(entry.Owned)})
+	}
+	return []string{"NUMBER", "SPECIES", "STATUS", "OWNED"}, rows
+}
+
+// idList formats Pokemon IDs as "(#1, #4)", or "" when there are none.
+func idList(ids []int) string {
+	if len(ids) == 0 {
+		return ""
+	}
+	parts := []string{}
+	for _, id := range ids {
+		parts = append(parts, fmt.Sprintf("#%d", id))
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
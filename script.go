@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// maxScriptDepth stops scripts that run themselves from recursing forever.
+const maxScriptDepth = 8
+
+type scriptSummary struct {
+	Commands  int  `json:"commands"`
+	Succeeded int  `json:"succeeded"`
+	Failed    int  `json:"failed"`
+	Stopped   bool `json:"stopped"`
+}
+
+func (s scriptSummary) renderPlain(w io.Writer) {
+	fmt.Fprintf(w, "ran %d commands: %d succeeded, %d failed\n", s.Commands, s.Succeeded, s.Failed)
+	if s.Stopped {
+		fmt.Fprintln(w, "stopped at the first error, use --continue to keep going")
+	}
+}
+
+// runScript runs every line of r as a REPL command. Blank lines and lines
+// starting with "#" are skipped. Unless config.ContinueOnError is set the
+// script stops at the first failing command. An exit command stops it and is
+// passed on as errExit, so the Pokedex shuts down.
+func runScript(config *Config, r io.Reader) (scriptSummary, error) {
+	summary := scriptSummary{}
+
+	if config.scriptDepth >= maxScriptDepth {
+		return summary, errors.New("scripts nested too deeply")
+	}
+	config.scriptDepth++
+	defer func() { config.scriptDepth-- }()
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if config.Echo {
+			echoCommand(config, line)
+		}
+
+		summary.Commands++
+		err := runCommand(config, line)
+		if errors.Is(err, errExit) {
+			summary.Succeeded++
+			return summary, err
+		}
+		if err != nil {
+			summary.Failed++
+			if !config.ContinueOnError {
+				summary.Stopped = true
+				break
+			}
+			continue
+		}
+		summary.Succeeded++
+	}
+
+	return summary, scanner.Err()
+}
+
+// echoCommand prints the command about to run. Structured formats keep stdout
+// for their documents, so the echo goes to stderr there.
+func echoCommand(config *Config, line string) {
+	w := io.Writer(os.Stdout)
+	if isStructured(config.Output) {
+		w = os.Stderr
+	}
+	fmt.Fprintf(w, "pokedex > %s\n", line)
+}
+
+func commandRun(config *Config, args cmdArgs) (any, error) {
+	if config.Battle != nil && args.arg(0) == "" {
+		return config.runFromBattle()
+	}
+	if args.arg(0) == "" {
+		return nil, usageError{commands["run"], "FILE is required outside of battles"}
+	}
+
+	file, err := os.Open(args.arg(0))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// --continue and --echo only hold for this script and the ones it runs.
+	defer func(continueOnError bool, echo bool) {
+		config.ContinueOnError, config.Echo = continueOnError, echo
+	}(config.ContinueOnError, config.Echo)
+	config.ContinueOnError = config.ContinueOnError || args.boolFlag("continue")
+	config.Echo = config.Echo || args.boolFlag("echo")
+
+	summary, err := runScript(config, file)
+	if err != nil {
+		return summary, err
+	}
+	if summary.Failed > 0 {
+		return summary, fmt.Errorf("%d of %d commands failed", summary.Failed, summary.Commands)
+	}
+
+	return summary, nil
+}
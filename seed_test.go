@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"pokedex/internal/pokecache"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testPokemon = `{
+	"name": "pikachu",
+	"species": {"name": "pikachu", "url": "https://pokeapi.co/api/v2/pokemon-species/25/"},
+	"stats": [
+		{"base_stat": 35, "stat": {"name": "hp"}},
+		{"base_stat": 55, "stat": {"name": "attack"}},
+		{"base_stat": 40, "stat": {"name": "defense"}},
+		{"base_stat": 50, "stat": {"name": "special-attack"}},
+		{"base_stat": 50, "stat": {"name": "special-defense"}},
+		{"base_stat": 90, "stat": {"name": "speed"}}
+	]
+}`
+
+const testSpecies = `{"name": "pikachu", "gender_rate": 4, "base_happiness": 70, "growth_rate": {"name": "medium"}}`
+
+// newTestConfig returns a Config that finds every nature in its cache, so
+// Pokemon can be rolled without the API.
+func newTestConfig(t *testing.T, seed int64) *Config {
+	config := &Config{
+		Nature:  "https://pokeapi.co/api/v2/nature/",
+		Pokedex: map[string]*DexEntry{},
+		Owned:   map[int]*CaughtPokemon{},
+		Bag:     maps.Clone(startingBag),
+		Cache:   *pokecache.NewCache(time.Hour),
+		Clock:   time.Now,
+	}
+	for _, name := range natures {
+		err := config.Cache.Add(config.Nature+name, []byte(fmt.Sprintf(`{"name": %q}`, name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	config.reseed(seed)
+	return config
+}
+
+func TestSameSeedSameRolls(t *testing.T) {
+	pokemon := Pokemon{}
+	species := PokemonSpecies{}
+	if err := json.Unmarshal([]byte(testPokemon), &pokemon); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(testSpecies), &species); err != nil {
+		t.Fatal(err)
+	}
+
+	// roll rolls a few wild Pokemon and explores a few times, the way a
+	// session would draw from the seed.
+	roll := func(seed int64) ([]*CaughtPokemon, []string) {
+		config := newTestConfig(t, seed)
+		wild := []*CaughtPokemon{}
+		found := []string{}
+		for level := 5; level <= 50; level += 5 {
+			caught, err := config.rollInstance(pokemon, species, level)
+			if err != nil {
+				t.Fatalf("Seed %v: rollInstance: %v", seed, err)
+			}
+			wild = append(wild, caught)
+			found = append(found, config.findItem())
+		}
+		return wild, found
+	}
+
+	cases := []int64{1, 42, -7, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC).UnixNano()}
+
+	for i, seed := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			wild, found := roll(seed)
+			again, foundAgain := roll(seed)
+
+			if !reflect.DeepEqual(wild, again) {
+				t.Errorf("Seed %v rolled different Pokemon", seed)
+			}
+			if !reflect.DeepEqual(found, foundAgain) {
+				t.Errorf("Seed %v found %v, then %v", seed, found, foundAgain)
+			}
+		})
+	}
+
+	t.Run("Different seeds", func(t *testing.T) {
+		wild, _ := roll(1)
+		other, _ := roll(2)
+		if reflect.DeepEqual(wild, other) {
+			t.Errorf("Seeds 1 and 2 rolled the same Pokemon")
+		}
+	})
+}
+
+func TestReseedInPlace(t *testing.T) {
+	cases := []int64{0, 1, 42}
+
+	for i, seed := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			config := newTestConfig(t, 99)
+			// A battle keeps the source it was started with.
+			held := config.Rand
+			held.Intn(100)
+
+			config.reseed(seed)
+			fresh := newTestConfig(t, seed)
+
+			for range 10 {
+				if a, b := held.Intn(1000), fresh.Rand.Intn(1000); a != b {
+					t.Fatalf("Seed %v: held source rolled %v, a new one %v", seed, a, b)
+				}
+			}
+		})
+	}
+}
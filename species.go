@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const language = "en"
+
+type speciesInfo struct {
+	Name          string   `json:"name"`
+	Genus         string   `json:"genus"`
+	FlavorText    string   `json:"flavor_text"`
+	Generation    string   `json:"generation"`
+	CaptureRate   int      `json:"capture_rate"`
+	BaseHappiness int      `json:"base_happiness"`
+	GrowthRate    string   `json:"growth_rate"`
+	EggGroups     []string `json:"egg_groups"`
+	IsLegendary   bool     `json:"is_legendary"`
+	IsMythical    bool     `json:"is_mythical"`
+}
+
+func newSpeciesInfo(species PokemonSpecies) speciesInfo {
+	info := speciesInfo{
+		Name:          species.Name,
+		Genus:         genus(species),
+		FlavorText:    flavorText(species),
+		Generation:    species.Generation.Name,
+		CaptureRate:   species.CaptureRate,
+		BaseHappiness: species.BaseHappiness,
+		GrowthRate:    species.GrowthRate.Name,
+		EggGroups:     []string{},
+		IsLegendary:   species.IsLegendary,
+		IsMythical:    species.IsMythical,
+	}
+
+	for _, group := range species.EggGroups {
+		info.EggGroups = append(info.EggGroups, group.Name)
+	}
+
+	return info
+}
+
+func (r speciesInfo) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s, the %s\n", r.Name, r.Genus)
+	r.renderDetails(w)
+	fmt.Fprintln(w)
+}
+
+// renderDetails prints everything but the name, so inspect can reuse it.
+func (r speciesInfo) renderDetails(w io.Writer) {
+	if r.FlavorText != "" {
+		fmt.Fprintf(w, "%s\n", r.FlavorText)
+	}
+	fmt.Fprintf(w, "Generation: %s\n", r.Generation)
+	fmt.Fprintf(w, "Capture Rate: %d\n", r.CaptureRate)
+	fmt.Fprintf(w, "Base Happiness: %d\n", r.BaseHappiness)
+	fmt.Fprintf(w, "Growth Rate: %s\n", r.GrowthRate)
+	fmt.Fprintf(w, "Egg Groups: %s\n", strings.Join(r.EggGroups, ", "))
+
+	if r.IsLegendary {
+		fmt.Fprintln(w, "Legendary Pokemon")
+	}
+	if r.IsMythical {
+		fmt.Fprintln(w, "Mythical Pokemon")
+	}
+}
+
+func (r speciesInfo) tableRows() ([]string, [][]string) {
+	return []string{"FIELD", "VALUE"}, r.rows()
+}
+
+func (r speciesInfo) rows() [][]string {
+	return [][]string{
+		{"species", r.Name},
+		{"genus", r.Genus},
+		{"flavor_text", r.FlavorText},
+		{"generation", r.Generation},
+		{"capture_rate", strconv.Itoa(r.CaptureRate)},
+		{"base_happiness", strconv.Itoa(r.BaseHappiness)},
+		{"growth_rate", r.GrowthRate},
+		{"egg_groups", strings.Join(r.EggGroups, ", ")},
+		{"legendary", strconv.FormatBool(r.IsLegendary)},
+		{"mythical", strconv.FormatBool(r.IsMythical)},
+	}
+}
+
+func commandSpecies(config *Config, args cmdArgs) (any, error) {
+	species, err := config.getSpecies(args.arg(0))
+	if err != nil {
+		return nil, err
+	}
+
+	return newSpeciesInfo(species), nil
+}
+
+func genus(species PokemonSpecies) string {
+	for _, genus := range species.Genera {
+		if genus.Language.Name == language {
+			return genus.Genus
+		}
+	}
+	return ""
+}
+
+// flavorText returns the most recent English Pokedex entry, flattened onto
+// one line since the API keeps the line breaks of the original games.
+func flavorText(species PokemonSpecies) string {
+	text := ""
+	for _, entry := range species.FlavorTextEntries {
+		if entry.Language.Name == language {
+			text = entry.FlavorText
+		}
+	}
+	return strings.Join(strings.Fields(text), " ")
+}
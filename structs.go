@@ -35,10 +35,13 @@ type Explore struct {
 		} `json:"pokemon"`
 		VersionDetails []struct {
 			EncounterDetails []struct {
-				Chance          int   `json:"chance"`
-				ConditionValues []any `json:"condition_values"`
-				MaxLevel        int   `json:"max_level"`
-				Method          struct {
+				Chance          int `json:"chance"`
+				ConditionValues []struct {
+					Name string `json:"name"`
+					URL  string `json:"url"`
+				} `json:"condition_values"`
+				MaxLevel int `json:"max_level"`
+				Method   struct {
 					Name string `json:"name"`
 					URL  string `json:"url"`
 				} `json:"method"`
@@ -311,3 +314,317 @@ type Pokemon struct {
 	} `json:"types"`
 	Weight int `json:"weight"`
 }
+
+type PokemonSpecies struct {
+	BaseHappiness int `json:"base_happiness"`
+	CaptureRate   int `json:"capture_rate"`
+	Color         struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"color"`
+	EggGroups []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"egg_groups"`
+	EvolutionChain struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+	EvolvesFromSpecies struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"evolves_from_species"`
+	FlavorTextEntries []struct {
+		FlavorText string `json:"flavor_text"`
+		Language   struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+		Version struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"version"`
+	} `json:"flavor_text_entries"`
+	FormsSwitchable bool `json:"forms_switchable"`
+	GenderRate      int  `json:"gender_rate"`
+	Genera          []struct {
+		Genus    string `json:"genus"`
+		Language struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+	} `json:"genera"`
+	Generation struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"generation"`
+	GrowthRate struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"growth_rate"`
+	Habitat struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"habitat"`
+	HasGenderDifferences bool   `json:"has_gender_differences"`
+	HatchCounter         int    `json:"hatch_counter"`
+	ID                   int    `json:"id"`
+	IsBaby               bool   `json:"is_baby"`
+	IsLegendary          bool   `json:"is_legendary"`
+	IsMythical           bool   `json:"is_mythical"`
+	Name                 string `json:"name"`
+	Names                []struct {
+		Language struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+		Name string `json:"name"`
+	} `json:"names"`
+	Order          int `json:"order"`
+	PokedexNumbers []struct {
+		EntryNumber int `json:"entry_number"`
+		Pokedex     struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"pokedex"`
+	} `json:"pokedex_numbers"`
+	Shape struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"shape"`
+	Varieties []struct {
+		IsDefault bool `json:"is_default"`
+		Pokemon   struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"pokemon"`
+	} `json:"varieties"`
+}
+
+type RegionalPokedex struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	IsMainSeries   bool   `json:"is_main_series"`
+	PokemonEntries []struct {
+		EntryNumber    int `json:"entry_number"`
+		PokemonSpecies struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"pokemon_species"`
+	} `json:"pokemon_entries"`
+	Region *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"region"`
+}
+
+type EvolutionChain struct {
+	BabyTriggerItem *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"baby_trigger_item"`
+	Chain EvolutionChainLink `json:"chain"`
+	ID    int                `json:"id"`
+}
+
+type EvolutionChainLink struct {
+	EvolutionDetails []EvolutionDetail    `json:"evolution_details"`
+	EvolvesTo        []EvolutionChainLink `json:"evolves_to"`
+	IsBaby           bool                 `json:"is_baby"`
+	Species          struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"species"`
+}
+
+type EvolutionDetail struct {
+	Gender   *int `json:"gender"`
+	HeldItem *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"held_item"`
+	Item *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"item"`
+	KnownMove *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"known_move"`
+	KnownMoveType *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"known_move_type"`
+	Location *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"location"`
+	MinAffection       *int `json:"min_affection"`
+	MinBeauty          *int `json:"min_beauty"`
+	MinHappiness       *int `json:"min_happiness"`
+	MinLevel           *int `json:"min_level"`
+	NeedsOverworldRain bool `json:"needs_overworld_rain"`
+	PartySpecies       *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"party_species"`
+	PartyType *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"party_type"`
+	RelativePhysicalStats *int   `json:"relative_physical_stats"`
+	TimeOfDay             string `json:"time_of_day"`
+	TradeSpecies          *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"trade_species"`
+	Trigger struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"trigger"`
+	TurnUpsideDown bool `json:"turn_upside_down"`
+}
+
+type Move struct {
+	Accuracy    *int `json:"accuracy"`
+	DamageClass struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"damage_class"`
+	EffectChance  *int `json:"effect_chance"`
+	EffectEntries []struct {
+		Effect   string `json:"effect"`
+		Language struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+		ShortEffect string `json:"short_effect"`
+	} `json:"effect_entries"`
+	FlavorTextEntries []struct {
+		FlavorText string `json:"flavor_text"`
+		Language   struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+		VersionGroup struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"version_group"`
+	} `json:"flavor_text_entries"`
+	ID   int `json:"id"`
+	Meta struct {
+		Ailment struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"ailment"`
+		AilmentChance int `json:"ailment_chance"`
+		Category      struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"category"`
+		CritRate     int  `json:"crit_rate"`
+		Drain        int  `json:"drain"`
+		FlinchChance int  `json:"flinch_chance"`
+		Healing      int  `json:"healing"`
+		MaxHits      *int `json:"max_hits"`
+		MaxTurns     *int `json:"max_turns"`
+		MinHits      *int `json:"min_hits"`
+		MinTurns     *int `json:"min_turns"`
+		StatChance   int  `json:"stat_chance"`
+	} `json:"meta"`
+	Name     string `json:"name"`
+	Power    *int   `json:"power"`
+	Pp       int    `json:"pp"`
+	Priority int    `json:"priority"`
+	Target   struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"target"`
+	Type struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"type"`
+}
+
+type Ability struct {
+	EffectEntries []struct {
+		Effect   string `json:"effect"`
+		Language struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+		ShortEffect string `json:"short_effect"`
+	} `json:"effect_entries"`
+	FlavorTextEntries []struct {
+		FlavorText string `json:"flavor_text"`
+		Language   struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+		VersionGroup struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"version_group"`
+	} `json:"flavor_text_entries"`
+	Generation struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"generation"`
+	ID           int    `json:"id"`
+	IsMainSeries bool   `json:"is_main_series"`
+	Name         string `json:"name"`
+	Pokemon      []struct {
+		IsHidden bool `json:"is_hidden"`
+		Pokemon  struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"pokemon"`
+		Slot int `json:"slot"`
+	} `json:"pokemon"`
+}
+
+type Item struct {
+	Attributes []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"attributes"`
+	Category struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"category"`
+	Cost          int `json:"cost"`
+	EffectEntries []struct {
+		Effect   string `json:"effect"`
+		Language struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+		ShortEffect string `json:"short_effect"`
+	} `json:"effect_entries"`
+	FlavorTextEntries []struct {
+		Language struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+		Text         string `json:"text"`
+		VersionGroup struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"version_group"`
+	} `json:"flavor_text_entries"`
+	FlingPower *int   `json:"fling_power"`
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+}
+
+type Nature struct {
+	DecreasedStat *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"decreased_stat"`
+	ID            int `json:"id"`
+	IncreasedStat *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"increased_stat"`
+	Name string `json:"name"`
+}
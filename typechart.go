@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"pokedex/internal/types"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type typeResult struct {
+	Name      string          `json:"name"`
+	Relations types.Relations `json:"damage_relations"`
+}
+
+func (r typeResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Type: %s\n", r.Name)
+	fmt.Fprintln(w, "Attacking:")
+	fmt.Fprintf(w, "   super effective against: %s\n", typeList(r.Relations.DoubleDamageTo))
+	fmt.Fprintf(w, "   not very effective against: %s\n", typeList(r.Relations.HalfDamageTo))
+	fmt.Fprintf(w, "   no effect on: %s\n", typeList(r.Relations.NoDamageTo))
+	fmt.Fprintln(w, "Defending:")
+	fmt.Fprintf(w, "   weak to: %s\n", typeList(r.Relations.DoubleDamageFrom))
+	fmt.Fprintf(w, "   resists: %s\n", typeList(r.Relations.HalfDamageFrom))
+	fmt.Fprintf(w, "   immune to: %s\n", typeList(r.Relations.NoDamageFrom))
+	fmt.Fprintln(w)
+}
+
+func (r typeResult) tableRows() ([]string, [][]string) {
+	return []string{"RELATION", "TYPES"}, [][]string{
+		{"double_damage_to", typeList(r.Relations.DoubleDamageTo)},
+		{"half_damage_to", typeList(r.Relations.HalfDamageTo)},
+		{"no_damage_to", typeList(r.Relations.NoDamageTo)},
+		{"double_damage_from", typeList(r.Relations.DoubleDamageFrom)},
+		{"half_damage_from", typeList(r.Relations.HalfDamageFrom)},
+		{"no_damage_from", typeList(r.Relations.NoDamageFrom)},
+	}
+}
+
+func typeList(names []string) string {
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ", ")
+}
+
+type typeMultiplier struct {
+	Type       string  `json:"type"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+type weaknessResult struct {
+	Pokemon     string           `json:"pokemon"`
+	Types       []string         `json:"types"`
+	Multipliers []typeMultiplier `json:"multipliers"`
+}
+
+func (r weaknessResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s (%s) takes:\n", r.Pokemon, strings.Join(r.Types, "/"))
+
+	groups := [][]string{}
+	last := -1.0
+	for _, m := range r.Multipliers {
+		if m.Multiplier != last {
+			groups = append(groups, []string{formatMultiplier(m.Multiplier)})
+			last = m.Multiplier
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], m.Type)
+	}
+
+	for _, group := range groups {
+		fmt.Fprintf(w, "   %s from %s\n", group[0], strings.Join(group[1:], ", "))
+	}
+
+	fmt.Fprintln(w)
+}
+
+func (r weaknessResult) tableRows() ([]string, [][]string) {
+	rows := [][]string{}
+	for _, m := range r.Multipliers {
+		rows = append(rows, []string{m.Type, formatMultiplier(m.Multiplier)})
+	}
+	return []string{"ATTACKING TYPE", "DAMAGE"}, rows
+}
+
+type matchupSide struct {
+	Name  string   `json:"name"`
+	Types []string `json:"types"`
+}
+
+type matchupResult struct {
+	Attacker matchupSide      `json:"attacker"`
+	Defender matchupSide      `json:"defender"`
+	Attacks  []typeMultiplier `json:"attacks"`
+	Counters []typeMultiplier `json:"counters"`
+}
+
+func (r matchupResult) renderPlain(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s attacking %s (%s):\n", r.Attacker.Name, r.Defender.Name, strings.Join(r.Defender.Types, "/"))
+	for _, m := range r.Attacks {
+		fmt.Fprintf(w, "   %s moves: %s\n", m.Type, formatMultiplier(m.Multiplier))
+	}
+	fmt.Fprintf(w, "%s attacking %s (%s):\n", r.Defender.Name, r.Attacker.Name, strings.Join(r.Attacker.Types, "/"))
+	for _, m := range r.Counters {
+		fmt.Fprintf(w, "   %s moves: %s\n", m.Type, formatMultiplier(m.Multiplier))
+	}
+	fmt.Fprintln(w)
+}
+
+func (r matchupResult) tableRows() ([]string, [][]string) {
+	rows := [][]string{}
+	for _, m := range r.Attacks {
+		rows = append(rows, []string{r.Attacker.Name, m.Type, r.Defender.Name, formatMultiplier(m.Multiplier)})
+	}
+	for _, m := range r.Counters {
+		rows = append(rows, []string{r.Defender.Name, m.Type, r.Attacker.Name, formatMultiplier(m.Multiplier)})
+	}
+	return []string{"ATTACKER", "MOVE TYPE", "DEFENDER", "DAMAGE"}, rows
+}
+
+func formatMultiplier(multiplier float64) string {
+	return strconv.FormatFloat(multiplier, 'g', -1, 64) + "x"
+}
+
+// fetchType is the fetch function the type chart loads types with.
+func (config *Config) fetchType(name string, v any) error {
+	return config.fetch(config.Type+name, v)
+}
+
+func commandType(config *Config, args cmdArgs) (any, error) {
+	t, err := config.TypeChart.Type(args.arg(0))
+	if err != nil {
+		return nil, err
+	}
+
+	return typeResult{Name: t.Name, Relations: t.Relations()}, nil
+}
+
+func commandWeakness(config *Config, args cmdArgs) (any, error) {
+	pokemon, err := config.getPokemon(args.arg(0))
+	if err != nil {
+		return nil, err
+	}
+
+	result := weaknessResult{
+		Pokemon:     pokemon.Name,
+		Types:       pokemonTypes(pokemon),
+		Multipliers: []typeMultiplier{},
+	}
+
+	defense, err := config.TypeChart.Defense(result.Types...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, attack := range types.All {
+		if defense[attack] != 1 {
+			result.Multipliers = append(result.Multipliers, typeMultiplier{Type: attack, Multiplier: defense[attack]})
+		}
+	}
+
+	sort.SliceStable(result.Multipliers, func(i, j int) bool {
+		return result.Multipliers[i].Multiplier > result.Multipliers[j].Multiplier
+	})
+
+	return result, nil
+}
+
+func commandMatchup(config *Config, args cmdArgs) (any, error) {
+	attacker, err := config.matchupSide(args.arg(0))
+	if err != nil {
+		return nil, err
+	}
+
+	defender, err := config.matchupSide(args.arg(1))
+	if err != nil {
+		return nil, err
+	}
+
+	attacks, err := config.multipliers(attacker.Types, defender.Types)
+	if err != nil {
+		return nil, err
+	}
+
+	counters, err := config.multipliers(defender.Types, attacker.Types)
+	if err != nil {
+		return nil, err
+	}
+
+	return matchupResult{
+		Attacker: attacker,
+		Defender: defender,
+		Attacks:  attacks,
+		Counters: counters,
+	}, nil
+}
+
+// matchupSide resolves a matchup argument, which may be a type or a Pokemon.
+func (config *Config) matchupSide(name string) (matchupSide, error) {
+	if types.IsType(name) {
+		return matchupSide{Name: name, Types: []string{name}}, nil
+	}
+
+	pokemon, err := config.getPokemon(name)
+	if err != nil {
+		return matchupSide{}, err
+	}
+
+	return matchupSide{Name: pokemon.Name, Types: pokemonTypes(pokemon)}, nil
+}
+
+func (config *Config) multipliers(attacks []string, defenders []string) ([]typeMultiplier, error) {
+	result := []typeMultiplier{}
+
+	for _, attack := range attacks {
+		multiplier, err := config.TypeChart.Effectiveness(attack, defenders...)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, typeMultiplier{Type: attack, Multiplier: multiplier})
+	}
+
+	return result, nil
+}
+
+func pokemonTypes(pokemon Pokemon) []string {
+	names := []string{}
+	for _, t := range pokemon.Types {
+		names = append(names, t.Type.Name)
+	}
+	return names
+}
+
+func completeTypes(config *Config, before []string) []string {
+	return types.All
+}
+
+func completeMatchup(config *Config, before []string) []string {
+	return append(completePokemon(config, before), types.All...)
+}